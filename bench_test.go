@@ -79,6 +79,75 @@ func BenchmarkSuperheroEncode(b *testing.B) {
 	}
 }
 
+func BenchmarkSuperheroDecodeZeroCopy(b *testing.B) {
+	data, err := os.ReadFile("testdata/superhero.bin")
+	if err != nil {
+		panic(err)
+	}
+
+	schema, err := avro.ParseFiles("testdata/superhero.avsc")
+	if err != nil {
+		panic(err)
+	}
+
+	api := avro.Config{ZeroCopy: true}.Freeze()
+	super := &Superhero{}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = api.Unmarshal(schema, data, super)
+	}
+}
+
+func BenchmarkSuperheroCodecDecode(b *testing.B) {
+	data, err := os.ReadFile("testdata/superhero.bin")
+	if err != nil {
+		panic(err)
+	}
+
+	schema, err := avro.ParseFiles("testdata/superhero.avsc")
+	if err != nil {
+		panic(err)
+	}
+
+	codec := avro.NewCodecForSchema[Superhero](schema)
+	super := &Superhero{}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = codec.DecodeInto(data, super)
+	}
+}
+
+func BenchmarkSuperheroCodecEncode(b *testing.B) {
+	schema, err := avro.ParseFiles("testdata/superhero.avsc")
+	if err != nil {
+		panic(err)
+	}
+
+	codec := avro.NewCodecForSchema[*Superhero](schema)
+	super := &Superhero{
+		ID:            234765,
+		AffiliationID: 9867,
+		Name:          "Wolverine",
+		Life:          85.25,
+		Energy:        32.75,
+		Powers: []*Superpower{
+			{ID: 2345, Name: "Bone Claws", Damage: 5, Energy: 1.15, Passive: false},
+			{ID: 2346, Name: "Regeneration", Damage: -2, Energy: 0.55, Passive: true},
+			{ID: 2347, Name: "Adamant skeleton", Damage: -10, Energy: 0, Passive: true},
+		},
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = codec.Encode(super)
+	}
+}
+
 func BenchmarkPartialSuperheroDecode(b *testing.B) {
 	data, err := os.ReadFile("testdata/superhero.bin")
 	if err != nil {
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/kjuulh/avro/v2"
+	"github.com/kjuulh/avro/v2/ocf"
+)
+
+type config struct {
+	Codec        string
+	Level        int
+	BlockLength  int
+	ReaderSchema string
+}
+
+func main() {
+	os.Exit(realMain(os.Args, os.Stdout, os.Stderr))
+}
+
+func realMain(args []string, stdout, stderr io.Writer) int {
+	var cfg config
+	flgs := flag.NewFlagSet("avroconv", flag.ExitOnError)
+	flgs.SetOutput(stderr)
+	flgs.StringVar(&cfg.Codec, "codec", "", "The compression codec of the output file. Defaults to the input file codec.")
+	flgs.IntVar(&cfg.Level, "level", 0, "The compression level of the codec. Defaults to the codec default.")
+	flgs.IntVar(&cfg.BlockLength, "block-length", 0, "The number of values in a block of the output file.")
+	flgs.StringVar(&cfg.ReaderSchema, "reader-schema", "", "The schema file of the output file, the input values are resolved into it.")
+	flgs.Usage = func() {
+		_, _ = fmt.Fprintln(stderr, "Usage: avroconv [options] input output")
+		_, _ = fmt.Fprintln(stderr, "Options:")
+		flgs.PrintDefaults()
+	}
+	if err := flgs.Parse(args[1:]); err != nil {
+		return 1
+	}
+	if flgs.NArg() != 2 {
+		_, _ = fmt.Fprintln(stderr, "Error: an input and output file are required")
+		return 1
+	}
+
+	tcfg := ocf.TranscodeConfig{
+		Codec:            ocf.CodecName(cfg.Codec),
+		CompressionLevel: cfg.Level,
+		BlockLength:      cfg.BlockLength,
+	}
+	if cfg.ReaderSchema != "" {
+		schema, err := avro.ParseFiles(cfg.ReaderSchema)
+		if err != nil {
+			_, _ = fmt.Fprintf(stderr, "Error: %v\n", err)
+			return 2
+		}
+		tcfg.ReaderSchema = schema
+	}
+
+	if err := transcode(flgs.Arg(0), flgs.Arg(1), tcfg); err != nil {
+		_, _ = fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	return 0
+}
+
+func transcode(in, out string, cfg ocf.TranscodeConfig) error {
+	src, err := os.Open(in)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = src.Close() }()
+
+	dst, err := os.Create(out)
+	if err != nil {
+		return err
+	}
+
+	if err = ocf.Transcode(dst, src, cfg); err != nil {
+		_ = dst.Close()
+		_ = os.Remove(out)
+		return err
+	}
+	return dst.Close()
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kjuulh/avro/v2/ocf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Record struct {
+	ID   int64  `avro:"id"`
+	Name string `avro:"name"`
+}
+
+func TestAvroConv_RequiredFlags(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         []string
+		wantExitCode int
+	}{
+		{
+			name:         "validates no files are set",
+			args:         []string{"avroconv"},
+			wantExitCode: 1,
+		},
+		{
+			name:         "validates output file is set",
+			args:         []string{"avroconv", "some/file"},
+			wantExitCode: 1,
+		},
+		{
+			name:         "validates input file exists",
+			args:         []string{"avroconv", "some/file", "some/other"},
+			wantExitCode: 2,
+		},
+		{
+			name:         "validates reader schema exists",
+			args:         []string{"avroconv", "-reader-schema", "some/schema", "some/file", "some/other"},
+			wantExitCode: 2,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			got := realMain(test.args, io.Discard, io.Discard)
+
+			assert.Equal(t, test.wantExitCode, got)
+		})
+	}
+}
+
+func TestAvroConv_Transcodes(t *testing.T) {
+	in := writeFile(t, 5)
+	out := filepath.Join(t.TempDir(), "out.avro")
+
+	args := []string{"avroconv", "-codec", "zstandard", "-level", "3", "-block-length", "2", "-reader-schema", "testdata/reader.avsc", in, out}
+	got := realMain(args, io.Discard, io.Discard)
+	require.Equal(t, 0, got)
+
+	f, err := os.Open(out)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = f.Close() })
+
+	dec, err := ocf.NewDecoder(f)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("zstandard"), dec.Metadata()["avro.codec"])
+	var recs []Record
+	for dec.HasNext() {
+		var rec Record
+		require.NoError(t, dec.Decode(&rec))
+		recs = append(recs, rec)
+	}
+	require.NoError(t, dec.Error())
+	assert.Len(t, recs, 5)
+	assert.Equal(t, Record{ID: 4, Name: "none"}, recs[4])
+}
+
+func TestAvroConv_RemovesOutputOnError(t *testing.T) {
+	in := filepath.Join(t.TempDir(), "in.avro")
+	require.NoError(t, os.WriteFile(in, []byte("not an avro file"), 0o644))
+	out := filepath.Join(t.TempDir(), "out.avro")
+
+	stderr := &bytes.Buffer{}
+	got := realMain([]string{"avroconv", in, out}, io.Discard, stderr)
+
+	assert.Equal(t, 2, got)
+	assert.Contains(t, stderr.String(), "Error:")
+	assert.NoFileExists(t, out)
+}
+
+func writeFile(t *testing.T, n int) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "in.avro")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = f.Close() })
+
+	enc, err := ocf.NewEncoder(`{"type":"record","name":"Record","fields":[{"name":"id","type":"long"}]}`, f, ocf.WithCodec(ocf.Deflate))
+	require.NoError(t, err)
+	for i := 0; i < n; i++ {
+		require.NoError(t, enc.Encode(struct {
+			ID int64 `avro:"id"`
+		}{ID: int64(i)}))
+	}
+	require.NoError(t, enc.Close())
+	return path
+}
@@ -73,20 +73,36 @@ func (c *frozenConfig) DecoderOf(schema Schema, typ reflect2.Type) ValDecoder {
 	}
 
 	ptrType := typ.(*reflect2.UnsafePtrType)
-	decoder = decoderOfType(c, schema, ptrType.Elem())
+	decoder = decoderOfType(newDecoderContext(c), schema, ptrType.Elem())
 	c.addDecoderToCache(key, rtype, decoder)
 	return decoder
 }
 
-func decoderOfType(cfg *frozenConfig, schema Schema, typ reflect2.Type) ValDecoder {
-	if dec := createDecoderOfMarshaler(cfg, schema, typ); dec != nil {
+// decoderContext holds the state of a single decoder construction.
+//
+// Record decoders are tracked by schema and type so that recursive
+// types resolve to the decoder being built instead of recursing forever.
+type decoderContext struct {
+	cfg      *frozenConfig
+	decoders map[cacheKey]ValDecoder
+}
+
+func newDecoderContext(cfg *frozenConfig) *decoderContext {
+	return &decoderContext{
+		cfg:      cfg,
+		decoders: make(map[cacheKey]ValDecoder),
+	}
+}
+
+func decoderOfType(d *decoderContext, schema Schema, typ reflect2.Type) ValDecoder {
+	if dec := createDecoderOfMarshaler(d, schema, typ); dec != nil {
 		return dec
 	}
 
 	// Handle eface case when it isnt a union
 	if typ.Kind() == reflect.Interface && schema.Type() != Union {
 		if _, ok := typ.(*reflect2.UnsafeIFaceType); !ok {
-			return newEfaceDecoder(cfg, schema)
+			return newEfaceDecoder(d, schema)
 		}
 	}
 
@@ -95,22 +111,30 @@ func decoderOfType(cfg *frozenConfig, schema Schema, typ reflect2.Type) ValDecod
 		return createDecoderOfNative(schema.(*PrimitiveSchema), typ)
 
 	case Record:
-		return createDecoderOfRecord(cfg, schema, typ)
+		key := cacheKey{fingerprint: cacheFingerprintOf(schema), rtype: typ.RType()}
+		if dec, ok := d.decoders[key]; ok {
+			return dec
+		}
+
+		dec := &deferDecoder{}
+		d.decoders[key] = dec
+		dec.decoder = createDecoderOfRecord(d, schema, typ)
+		return dec.decoder
 
 	case Ref:
-		return decoderOfType(cfg, schema.(*RefSchema).Schema(), typ)
+		return decoderOfType(d, schema.(*RefSchema).Schema(), typ)
 
 	case Enum:
 		return createDecoderOfEnum(schema, typ)
 
 	case Array:
-		return createDecoderOfArray(cfg, schema, typ)
+		return createDecoderOfArray(d, schema, typ)
 
 	case Map:
-		return createDecoderOfMap(cfg, schema, typ)
+		return createDecoderOfMap(d, schema, typ)
 
 	case Union:
-		return createDecoderOfUnion(cfg, schema, typ)
+		return createDecoderOfUnion(d, schema, typ)
 
 	case Fixed:
 		return createDecoderOfFixed(schema, typ)
@@ -132,7 +156,7 @@ func (c *frozenConfig) EncoderOf(schema Schema, typ reflect2.Type) ValEncoder {
 		return encoder
 	}
 
-	encoder = encoderOfType(c, schema, typ)
+	encoder = encoderOfType(newEncoderContext(c), schema, typ)
 	if typ.LikePtr() {
 		encoder = &onePtrEncoder{encoder}
 	}
@@ -148,8 +172,24 @@ func (e *onePtrEncoder) Encode(ptr unsafe.Pointer, w *Writer) {
 	e.enc.Encode(noescape(unsafe.Pointer(&ptr)), w)
 }
 
-func encoderOfType(cfg *frozenConfig, schema Schema, typ reflect2.Type) ValEncoder {
-	if enc := createEncoderOfMarshaler(cfg, schema, typ); enc != nil {
+// encoderContext holds the state of a single encoder construction.
+//
+// Record encoders are tracked by schema and type so that recursive
+// types resolve to the encoder being built instead of recursing forever.
+type encoderContext struct {
+	cfg      *frozenConfig
+	encoders map[cacheKey]ValEncoder
+}
+
+func newEncoderContext(cfg *frozenConfig) *encoderContext {
+	return &encoderContext{
+		cfg:      cfg,
+		encoders: make(map[cacheKey]ValEncoder),
+	}
+}
+
+func encoderOfType(e *encoderContext, schema Schema, typ reflect2.Type) ValEncoder {
+	if enc := createEncoderOfMarshaler(e, schema, typ); enc != nil {
 		return enc
 	}
 
@@ -162,22 +202,30 @@ func encoderOfType(cfg *frozenConfig, schema Schema, typ reflect2.Type) ValEncod
 		return createEncoderOfNative(schema, typ)
 
 	case Record:
-		return createEncoderOfRecord(cfg, schema, typ)
+		key := cacheKey{fingerprint: schema.Fingerprint(), rtype: typ.RType()}
+		if enc, ok := e.encoders[key]; ok {
+			return enc
+		}
+
+		enc := &deferEncoder{}
+		e.encoders[key] = enc
+		enc.encoder = createEncoderOfRecord(e, schema, typ)
+		return enc.encoder
 
 	case Ref:
-		return encoderOfType(cfg, schema.(*RefSchema).Schema(), typ)
+		return encoderOfType(e, schema.(*RefSchema).Schema(), typ)
 
 	case Enum:
 		return createEncoderOfEnum(schema, typ)
 
 	case Array:
-		return createEncoderOfArray(cfg, schema, typ)
+		return createEncoderOfArray(e, schema, typ)
 
 	case Map:
-		return createEncoderOfMap(cfg, schema, typ)
+		return createEncoderOfMap(e, schema, typ)
 
 	case Union:
-		return createEncoderOfUnion(cfg, schema, typ)
+		return createEncoderOfUnion(e, schema, typ)
 
 	case Fixed:
 		return createEncoderOfFixed(schema, typ)
@@ -188,6 +236,24 @@ func encoderOfType(cfg *frozenConfig, schema Schema, typ reflect2.Type) ValEncod
 	}
 }
 
+// deferDecoder delegates to a record decoder that is still being built.
+type deferDecoder struct {
+	decoder ValDecoder
+}
+
+func (d *deferDecoder) Decode(ptr unsafe.Pointer, r *Reader) {
+	d.decoder.Decode(ptr, r)
+}
+
+// deferEncoder delegates to a record encoder that is still being built.
+type deferEncoder struct {
+	encoder ValEncoder
+}
+
+func (e *deferEncoder) Encode(ptr unsafe.Pointer, w *Writer) {
+	e.encoder.Encode(ptr, w)
+}
+
 type errorDecoder struct {
 	err error
 }
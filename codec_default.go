@@ -7,13 +7,25 @@ import (
 	"github.com/modern-go/reflect2"
 )
 
-func createDefaultDecoder(cfg *frozenConfig, field *Field, typ reflect2.Type) ValDecoder {
+func createDefaultDecoder(d *decoderContext, field *Field, typ reflect2.Type) ValDecoder {
+	b, err := encodeFieldDefault(d.cfg, field)
+	if err != nil {
+		return &errorDecoder{err: fmt.Errorf("decode default: %w", err)}
+	}
+	return &defaultDecoder{
+		data:    b,
+		decoder: decoderOfType(d, field.Type(), typ),
+	}
+}
+
+// encodeFieldDefault returns the binary encoding of the field default.
+func encodeFieldDefault(cfg *frozenConfig, field *Field) ([]byte, error) {
 	fn := func(def any) ([]byte, error) {
 		defaultType := reflect2.TypeOf(def)
 		if defaultType == nil {
 			defaultType = reflect2.TypeOf((*null)(nil))
 		}
-		defaultEncoder := encoderOfType(cfg, field.Type(), defaultType)
+		defaultEncoder := encoderOfType(newEncoderContext(cfg), field.Type(), defaultType)
 		if defaultType.LikePtr() {
 			defaultEncoder = &onePtrEncoder{defaultEncoder}
 		}
@@ -31,14 +43,7 @@ func createDefaultDecoder(cfg *frozenConfig, field *Field, typ reflect2.Type) Va
 		return data, nil
 	}
 
-	b, err := field.encodeDefault(fn)
-	if err != nil {
-		return &errorDecoder{err: fmt.Errorf("decode default: %w", err)}
-	}
-	return &defaultDecoder{
-		data:    b,
-		decoder: decoderOfType(cfg, field.Type(), typ),
-	}
+	return field.encodeDefault(fn)
 }
 
 type defaultDecoder struct {
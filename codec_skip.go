@@ -6,7 +6,29 @@ import (
 )
 
 func createSkipDecoder(schema Schema) ValDecoder {
+	return skipDecoderOfType(map[*RecordSchema]*deferDecoder{}, schema)
+}
+
+func (c *frozenConfig) skipDecoderOf(schema Schema) ValDecoder {
+	fingerprint := cacheFingerprintOf(schema)
+	if dec, ok := c.skipCache.Load(fingerprint); ok {
+		return dec.(ValDecoder)
+	}
+
+	dec := createSkipDecoder(schema)
+	if !c.config.DisableCaching {
+		c.skipCache.Store(fingerprint, dec)
+	}
+	return dec
+}
+
+// skipDecoderOfType creates a skip decoder, tracking the records being built
+// in seen so that recursive schemas terminate.
+func skipDecoderOfType(seen map[*RecordSchema]*deferDecoder, schema Schema) ValDecoder {
 	switch schema.Type() {
+	case Null:
+		return &nullSkipDecoder{}
+
 	case Boolean:
 		return &boolSkipDecoder{}
 
@@ -29,19 +51,27 @@ func createSkipDecoder(schema Schema) ValDecoder {
 		return &bytesSkipDecoder{}
 
 	case Record:
-		return skipDecoderOfRecord(schema)
+		rec := schema.(*RecordSchema)
+		if dec, ok := seen[rec]; ok {
+			return dec
+		}
+
+		dec := &deferDecoder{}
+		seen[rec] = dec
+		dec.decoder = skipDecoderOfRecord(seen, rec)
+		return dec.decoder
 
 	case Ref:
-		return createSkipDecoder(schema.(*RefSchema).Schema())
+		return skipDecoderOfType(seen, schema.(*RefSchema).Schema())
 
 	case Enum:
 		return &enumSkipDecoder{symbols: schema.(*EnumSchema).Symbols()}
 
 	case Array:
-		return skipDecoderOfArray(schema)
+		return skipDecoderOfArray(seen, schema)
 
 	case Map:
-		return skipDecoderOfMap(schema)
+		return skipDecoderOfMap(seen, schema)
 
 	case Union:
 		return skipDecoderOfUnion(schema)
@@ -54,6 +84,10 @@ func createSkipDecoder(schema Schema) ValDecoder {
 	}
 }
 
+type nullSkipDecoder struct{}
+
+func (*nullSkipDecoder) Decode(_ unsafe.Pointer, _ *Reader) {}
+
 type boolSkipDecoder struct{}
 
 func (*boolSkipDecoder) Decode(_ unsafe.Pointer, r *Reader) {
@@ -96,12 +130,10 @@ func (c *bytesSkipDecoder) Decode(_ unsafe.Pointer, r *Reader) {
 	r.SkipBytes()
 }
 
-func skipDecoderOfRecord(schema Schema) ValDecoder {
-	rec := schema.(*RecordSchema)
-
+func skipDecoderOfRecord(seen map[*RecordSchema]*deferDecoder, rec *RecordSchema) ValDecoder {
 	decoders := make([]ValDecoder, len(rec.Fields()))
 	for i, field := range rec.Fields() {
-		decoders[i] = createSkipDecoder(field.Type())
+		decoders[i] = skipDecoderOfType(seen, field.Type())
 	}
 
 	return &recordSkipDecoder{
@@ -127,9 +159,9 @@ func (c *enumSkipDecoder) Decode(_ unsafe.Pointer, r *Reader) {
 	r.SkipInt()
 }
 
-func skipDecoderOfArray(schema Schema) ValDecoder {
+func skipDecoderOfArray(seen map[*RecordSchema]*deferDecoder, schema Schema) ValDecoder {
 	arr := schema.(*ArraySchema)
-	decoder := createSkipDecoder(arr.Items())
+	decoder := skipDecoderOfType(seen, arr.Items())
 
 	return &sliceSkipDecoder{
 		decoder: decoder,
@@ -158,9 +190,9 @@ func (d *sliceSkipDecoder) Decode(_ unsafe.Pointer, r *Reader) {
 	}
 }
 
-func skipDecoderOfMap(schema Schema) ValDecoder {
+func skipDecoderOfMap(seen map[*RecordSchema]*deferDecoder, schema Schema) ValDecoder {
 	m := schema.(*MapSchema)
-	decoder := createSkipDecoder(m.Values())
+	decoder := skipDecoderOfType(seen, m.Values())
 
 	return &mapSkipDecoder{
 		decoder: decoder,
@@ -0,0 +1,183 @@
+package avro
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"unsafe"
+
+	"github.com/modern-go/reflect2"
+)
+
+// Codec encodes and decodes values of type T with a fixed schema.
+//
+// The value encoder and decoder are resolved when the codec is created,
+// skipping the cache lookups done by Marshal and Unmarshal.
+type Codec[T any] struct {
+	cfg    *frozenConfig
+	schema Schema
+	enc    ValEncoder
+	dec    ValDecoder
+}
+
+// NewCodec returns a codec for T using schema s.
+func NewCodec[T any](s string) (*Codec[T], error) {
+	sch, err := Parse(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCodecForSchema[T](sch), nil
+}
+
+// NewCodecForSchema returns a codec for T using schema.
+func NewCodecForSchema[T any](schema Schema) *Codec[T] {
+	return newCodec[T](DefaultConfig.(*frozenConfig), schema)
+}
+
+// NewCodecWithConfig returns a codec for T using schema and the given API.
+// The API must be created with Config.Freeze.
+func NewCodecWithConfig[T any](api API, schema Schema) (*Codec[T], error) {
+	cfg, ok := api.(*frozenConfig)
+	if !ok {
+		return nil, fmt.Errorf("avro: unsupported api %T", api)
+	}
+	return newCodec[T](cfg, schema), nil
+}
+
+func newCodec[T any](cfg *frozenConfig, schema Schema) *Codec[T] {
+	ptrType := reflect2.TypeOf((*T)(nil)).(*reflect2.UnsafePtrType)
+
+	return &Codec[T]{
+		cfg:    cfg,
+		schema: schema,
+		enc:    encoderOfType(newEncoderContext(cfg), schema, ptrType.Elem()),
+		dec:    cfg.DecoderOf(schema, ptrType),
+	}
+}
+
+// Schema returns the schema of the codec.
+func (c *Codec[T]) Schema() Schema {
+	return c.schema
+}
+
+// Encode returns the Avro encoding of v.
+func (c *Codec[T]) Encode(v T) ([]byte, error) {
+	w := c.cfg.borrowWriter()
+	defer c.cfg.returnWriter(w)
+
+	c.enc.Encode(unsafe.Pointer(&v), w)
+	if err := w.Error; err != nil {
+		return nil, err
+	}
+
+	result := w.Buffer()
+	copied := make([]byte, len(result))
+	copy(copied, result)
+	return copied, nil
+}
+
+// AppendEncode appends the Avro encoding of v to dst and returns the extended buffer.
+// On error, dst is returned unchanged.
+func (c *Codec[T]) AppendEncode(dst []byte, v T) ([]byte, error) {
+	w := c.cfg.borrowWriter()
+	buf := w.buf
+	w.buf = dst
+
+	c.enc.Encode(unsafe.Pointer(&v), w)
+	out, err := w.buf, w.Error
+
+	w.buf = buf
+	c.cfg.returnWriter(w)
+	if err != nil {
+		return dst, err
+	}
+	return out, nil
+}
+
+// Decode parses the Avro encoded data and returns the decoded value.
+func (c *Codec[T]) Decode(data []byte) (T, error) {
+	var v T
+	err := c.DecodeInto(data, &v)
+	return v, err
+}
+
+// DecodeInto parses the Avro encoded data and stores the result in the value pointed to by v.
+func (c *Codec[T]) DecodeInto(data []byte, v *T) error {
+	if v == nil {
+		return errors.New("avro: can not decode into nil pointer")
+	}
+
+	r := c.cfg.borrowReader(data)
+	r.zeroCopy = c.cfg.config.ZeroCopy
+	c.dec.Decode(unsafe.Pointer(v), r)
+	err := r.Error
+	c.cfg.returnReader(r)
+
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	return err
+}
+
+// NewEncoder returns a new encoder that writes values of T to w.
+func (c *Codec[T]) NewEncoder(w io.Writer) *CodecEncoder[T] {
+	writer, ok := w.(*Writer)
+	if !ok {
+		writer = NewWriter(w, 512, WithWriterConfig(c.cfg))
+	}
+	return &CodecEncoder[T]{c: c, w: writer}
+}
+
+// NewDecoder returns a new decoder that reads values of T from r.
+func (c *Codec[T]) NewDecoder(r io.Reader) *CodecDecoder[T] {
+	return &CodecDecoder[T]{c: c, r: NewReader(r, 512, WithReaderConfig(c.cfg))}
+}
+
+// CodecEncoder writes values of T to an output stream.
+type CodecEncoder[T any] struct {
+	c *Codec[T]
+	w *Writer
+}
+
+// Encode writes the Avro encoding of v to the stream.
+func (e *CodecEncoder[T]) Encode(v T) error {
+	e.c.enc.Encode(unsafe.Pointer(&v), e.w)
+	_ = e.w.Flush()
+	return e.w.Error
+}
+
+// CodecDecoder reads values of T from an input stream.
+type CodecDecoder[T any] struct {
+	c *Codec[T]
+	r *Reader
+}
+
+// Decode reads the next Avro encoded value from its input and returns it.
+// At the end of the input, Decode returns io.EOF.
+func (d *CodecDecoder[T]) Decode() (T, error) {
+	var v T
+	err := d.DecodeInto(&v)
+	return v, err
+}
+
+// DecodeInto reads the next Avro encoded value from its input and stores it in the value pointed to by v.
+// At the end of the input, DecodeInto returns io.EOF.
+func (d *CodecDecoder[T]) DecodeInto(v *T) error {
+	if v == nil {
+		return errors.New("avro: can not decode into nil pointer")
+	}
+
+	if d.r.head == d.r.tail && d.r.reader != nil {
+		if !d.r.loadMore() {
+			return io.EOF
+		}
+	}
+
+	d.c.dec.Decode(unsafe.Pointer(v), d.r)
+
+	if errors.Is(d.r.Error, io.EOF) {
+		return nil
+	}
+	return d.r.Error
+}
@@ -0,0 +1,218 @@
+package avro_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/kjuulh/avro/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testCodecSchema = `{
+	"type": "record",
+	"name": "test",
+	"fields" : [
+		{"name": "a", "type": "long"},
+		{"name": "b", "type": "string"}
+	]
+}`
+
+func TestNewCodec_SchemaError(t *testing.T) {
+	defer ConfigTeardown()
+
+	_, err := avro.NewCodec[TestRecord]("{}")
+
+	assert.Error(t, err)
+}
+
+func TestCodec_Encode(t *testing.T) {
+	defer ConfigTeardown()
+
+	codec, err := avro.NewCodec[TestRecord](testCodecSchema)
+	require.NoError(t, err)
+
+	got, err := codec.Encode(TestRecord{A: 27, B: "foo"})
+
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x36, 0x06, 0x66, 0x6f, 0x6f}, got)
+}
+
+func TestCodec_EncodeError(t *testing.T) {
+	defer ConfigTeardown()
+
+	codec := avro.NewCodecForSchema[string](avro.MustParse(`{"type": "enum", "name": "en", "symbols": ["foo"]}`))
+
+	_, err := codec.Encode("bar")
+
+	assert.Error(t, err)
+}
+
+func TestCodec_EncodePtr(t *testing.T) {
+	defer ConfigTeardown()
+
+	codec, err := avro.NewCodec[*TestRecord](testCodecSchema)
+	require.NoError(t, err)
+
+	got, err := codec.Encode(&TestRecord{A: 27, B: "foo"})
+
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x36, 0x06, 0x66, 0x6f, 0x6f}, got)
+}
+
+func TestCodec_EncodeInterface(t *testing.T) {
+	defer ConfigTeardown()
+
+	codec := avro.NewCodecForSchema[any](avro.MustParse("long"))
+
+	got, err := codec.Encode(int64(27))
+
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x36}, got)
+}
+
+func TestCodec_AppendEncode(t *testing.T) {
+	defer ConfigTeardown()
+
+	codec := avro.NewCodecForSchema[int](avro.MustParse("int"))
+
+	got, err := codec.AppendEncode([]byte{0x01}, 27)
+
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x01, 0x36}, got)
+}
+
+func TestCodec_AppendEncodeError(t *testing.T) {
+	defer ConfigTeardown()
+
+	codec := avro.NewCodecForSchema[string](avro.MustParse(`{"type": "enum", "name": "en", "symbols": ["foo"]}`))
+	dst := []byte{0x01}
+
+	got, err := codec.AppendEncode(dst, "bar")
+
+	assert.Error(t, err)
+	assert.Equal(t, dst, got)
+}
+
+func TestCodec_Decode(t *testing.T) {
+	defer ConfigTeardown()
+
+	codec, err := avro.NewCodec[TestRecord](testCodecSchema)
+	require.NoError(t, err)
+
+	got, err := codec.Decode([]byte{0x36, 0x06, 0x66, 0x6f, 0x6f})
+
+	require.NoError(t, err)
+	assert.Equal(t, TestRecord{A: 27, B: "foo"}, got)
+}
+
+func TestCodec_DecodePtr(t *testing.T) {
+	defer ConfigTeardown()
+
+	codec, err := avro.NewCodec[*TestRecord](testCodecSchema)
+	require.NoError(t, err)
+
+	got, err := codec.Decode([]byte{0x36, 0x06, 0x66, 0x6f, 0x6f})
+
+	require.NoError(t, err)
+	assert.Equal(t, &TestRecord{A: 27, B: "foo"}, got)
+}
+
+func TestCodec_DecodeInterface(t *testing.T) {
+	defer ConfigTeardown()
+
+	codec, err := avro.NewCodec[any](testCodecSchema)
+	require.NoError(t, err)
+
+	got, err := codec.Decode([]byte{0x36, 0x06, 0x66, 0x6f, 0x6f})
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"a": int64(27), "b": "foo"}, got)
+}
+
+func TestCodec_DecodeError(t *testing.T) {
+	defer ConfigTeardown()
+
+	codec, err := avro.NewCodec[TestRecord](testCodecSchema)
+	require.NoError(t, err)
+
+	_, err = codec.Decode([]byte{0x36, 0x01})
+
+	assert.Error(t, err)
+}
+
+func TestCodec_DecodeUnsupportedType(t *testing.T) {
+	defer ConfigTeardown()
+
+	codec := avro.NewCodecForSchema[int](avro.MustParse("string"))
+
+	_, err := codec.Decode([]byte{0x06, 0x66, 0x6f, 0x6f})
+
+	assert.Error(t, err)
+}
+
+func TestCodec_DecodeIntoNilPtr(t *testing.T) {
+	defer ConfigTeardown()
+
+	codec := avro.NewCodecForSchema[int](avro.MustParse("int"))
+
+	err := codec.DecodeInto([]byte{0x36}, nil)
+
+	assert.Error(t, err)
+}
+
+func TestCodec_WithConfig(t *testing.T) {
+	defer ConfigTeardown()
+
+	type tagged struct {
+		A int64 `json:"a"`
+	}
+	api := avro.Config{TagKey: "json"}.Freeze()
+	schema := avro.MustParse(`{"type": "record", "name": "test", "fields": [{"name": "a", "type": "long"}]}`)
+	codec, err := avro.NewCodecWithConfig[tagged](api, schema)
+	require.NoError(t, err)
+
+	b, err := codec.Encode(tagged{A: 27})
+	require.NoError(t, err)
+	got, err := codec.Decode(b)
+
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x36}, b)
+	assert.Equal(t, tagged{A: 27}, got)
+}
+
+type testAPI struct {
+	avro.API
+}
+
+func TestNewCodecWithConfig_UnsupportedAPI(t *testing.T) {
+	defer ConfigTeardown()
+
+	_, err := avro.NewCodecWithConfig[int](testAPI{API: avro.DefaultConfig}, avro.MustParse("int"))
+
+	assert.Error(t, err)
+}
+
+func TestCodec_EncoderDecoder(t *testing.T) {
+	defer ConfigTeardown()
+
+	codec, err := avro.NewCodec[TestRecord](testCodecSchema)
+	require.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	enc := codec.NewEncoder(buf)
+	require.NoError(t, enc.Encode(TestRecord{A: 27, B: "foo"}))
+	require.NoError(t, enc.Encode(TestRecord{A: 28, B: "bar"}))
+
+	dec := codec.NewDecoder(buf)
+	got1, err := dec.Decode()
+	require.NoError(t, err)
+	got2, err := dec.Decode()
+	require.NoError(t, err)
+	_, err = dec.Decode()
+
+	assert.Equal(t, TestRecord{A: 27, B: "foo"}, got1)
+	assert.Equal(t, TestRecord{A: 28, B: "bar"}, got2)
+	assert.Equal(t, io.EOF, err)
+}
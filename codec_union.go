@@ -10,24 +10,24 @@ import (
 	"github.com/modern-go/reflect2"
 )
 
-func createDecoderOfUnion(cfg *frozenConfig, schema Schema, typ reflect2.Type) ValDecoder {
+func createDecoderOfUnion(d *decoderContext, schema Schema, typ reflect2.Type) ValDecoder {
 	switch typ.Kind() {
 	case reflect.Map:
 		if typ.(reflect2.MapType).Key().Kind() != reflect.String ||
 			typ.(reflect2.MapType).Elem().Kind() != reflect.Interface {
 			break
 		}
-		return decoderOfMapUnion(cfg, schema, typ)
+		return decoderOfMapUnion(d, schema, typ)
 
 	case reflect.Ptr:
 		if !schema.(*UnionSchema).Nullable() {
 			break
 		}
-		return decoderOfPtrUnion(cfg, schema, typ)
+		return decoderOfPtrUnion(d, schema, typ)
 
 	case reflect.Interface:
 		if _, ok := typ.(*reflect2.UnsafeIFaceType); !ok {
-			dec, err := decoderOfResolvedUnion(cfg, schema)
+			dec, err := decoderOfResolvedUnion(d, schema)
 			if err != nil {
 				return &errorDecoder{err: fmt.Errorf("avro: problem resolving decoder for Avro %s: %w", schema.Type(), err)}
 			}
@@ -39,26 +39,26 @@ func createDecoderOfUnion(cfg *frozenConfig, schema Schema, typ reflect2.Type) V
 	return &errorDecoder{err: fmt.Errorf("avro: %s is unsupported for Avro %s", typ.String(), schema.Type())}
 }
 
-func createEncoderOfUnion(cfg *frozenConfig, schema Schema, typ reflect2.Type) ValEncoder {
+func createEncoderOfUnion(e *encoderContext, schema Schema, typ reflect2.Type) ValEncoder {
 	switch typ.Kind() {
 	case reflect.Map:
 		if typ.(reflect2.MapType).Key().Kind() != reflect.String ||
 			typ.(reflect2.MapType).Elem().Kind() != reflect.Interface {
 			break
 		}
-		return encoderOfMapUnion(cfg, schema, typ)
+		return encoderOfMapUnion(e, schema, typ)
 
 	case reflect.Ptr:
 		if !schema.(*UnionSchema).Nullable() {
 			break
 		}
-		return encoderOfPtrUnion(cfg, schema, typ)
+		return encoderOfPtrUnion(e, schema, typ)
 	}
 
-	return encoderOfResolverUnion(cfg, schema, typ)
+	return encoderOfResolverUnion(e, schema, typ)
 }
 
-func decoderOfMapUnion(cfg *frozenConfig, schema Schema, typ reflect2.Type) ValDecoder {
+func decoderOfMapUnion(d *decoderContext, schema Schema, typ reflect2.Type) ValDecoder {
 	union := schema.(*UnionSchema)
 	mapType := typ.(*reflect2.UnsafeMapType)
 
@@ -67,11 +67,11 @@ func decoderOfMapUnion(cfg *frozenConfig, schema Schema, typ reflect2.Type) ValD
 		if s.Type() == Null {
 			continue
 		}
-		typeDecs[i] = newEfaceDecoder(cfg, s)
+		typeDecs[i] = newEfaceDecoder(d, s)
 	}
 
 	return &mapUnionDecoder{
-		cfg:      cfg,
+		cfg:      d.cfg,
 		schema:   union,
 		mapType:  mapType,
 		elemType: mapType.Elem(),
@@ -111,11 +111,11 @@ func (d *mapUnionDecoder) Decode(ptr unsafe.Pointer, r *Reader) {
 	d.mapType.UnsafeSetIndex(ptr, keyPtr, elemPtr)
 }
 
-func encoderOfMapUnion(cfg *frozenConfig, schema Schema, _ reflect2.Type) ValEncoder {
+func encoderOfMapUnion(e *encoderContext, schema Schema, _ reflect2.Type) ValEncoder {
 	union := schema.(*UnionSchema)
 
 	return &mapUnionEncoder{
-		cfg:    cfg,
+		cfg:    e.cfg,
 		schema: union,
 	}
 }
@@ -156,19 +156,19 @@ func (e *mapUnionEncoder) Encode(ptr unsafe.Pointer, w *Writer) {
 	elemType := reflect2.TypeOf(val)
 	elemPtr := reflect2.PtrOf(val)
 
-	encoder := encoderOfType(e.cfg, schema, elemType)
+	encoder := encoderOfType(newEncoderContext(e.cfg), schema, elemType)
 	if elemType.LikePtr() {
 		encoder = &onePtrEncoder{encoder}
 	}
 	encoder.Encode(elemPtr, w)
 }
 
-func decoderOfPtrUnion(cfg *frozenConfig, schema Schema, typ reflect2.Type) ValDecoder {
+func decoderOfPtrUnion(d *decoderContext, schema Schema, typ reflect2.Type) ValDecoder {
 	union := schema.(*UnionSchema)
 	_, typeIdx := union.Indices()
 	ptrType := typ.(*reflect2.UnsafePtrType)
 	elemType := ptrType.Elem()
-	decoder := decoderOfType(cfg, union.Types()[typeIdx], elemType)
+	decoder := decoderOfType(d, union.Types()[typeIdx], elemType)
 
 	return &unionPtrDecoder{
 		schema:  union,
@@ -206,11 +206,11 @@ func (d *unionPtrDecoder) Decode(ptr unsafe.Pointer, r *Reader) {
 	d.decoder.Decode(*((*unsafe.Pointer)(ptr)), r)
 }
 
-func encoderOfPtrUnion(cfg *frozenConfig, schema Schema, typ reflect2.Type) ValEncoder {
+func encoderOfPtrUnion(e *encoderContext, schema Schema, typ reflect2.Type) ValEncoder {
 	union := schema.(*UnionSchema)
 	nullIdx, typeIdx := union.Indices()
 	ptrType := typ.(*reflect2.UnsafePtrType)
-	encoder := encoderOfType(cfg, union.Types()[typeIdx], ptrType.Elem())
+	encoder := encoderOfType(e, union.Types()[typeIdx], ptrType.Elem())
 
 	return &unionPtrEncoder{
 		schema:  union,
@@ -237,7 +237,7 @@ func (e *unionPtrEncoder) Encode(ptr unsafe.Pointer, w *Writer) {
 	e.encoder.Encode(*((*unsafe.Pointer)(ptr)), w)
 }
 
-func decoderOfResolvedUnion(cfg *frozenConfig, schema Schema) (ValDecoder, error) {
+func decoderOfResolvedUnion(d *decoderContext, schema Schema) (ValDecoder, error) {
 	union := schema.(*UnionSchema)
 
 	types := make([]reflect2.Type, len(union.Types()))
@@ -245,13 +245,13 @@ func decoderOfResolvedUnion(cfg *frozenConfig, schema Schema) (ValDecoder, error
 	for i, schema := range union.Types() {
 		name := unionResolutionName(schema)
 
-		typ, err := cfg.resolver.Type(name)
+		typ, err := d.cfg.resolver.Type(name)
 		if err != nil {
-			if cfg.config.UnionResolutionError {
+			if d.cfg.config.UnionResolutionError {
 				return nil, err
 			}
 
-			if cfg.config.PartialUnionTypeResolution {
+			if d.cfg.config.PartialUnionTypeResolution {
 				decoders[i] = nil
 				types[i] = nil
 				continue
@@ -262,13 +262,13 @@ func decoderOfResolvedUnion(cfg *frozenConfig, schema Schema) (ValDecoder, error
 			break
 		}
 
-		decoder := decoderOfType(cfg, schema, typ)
+		decoder := decoderOfType(d, schema, typ)
 		decoders[i] = decoder
 		types[i] = typ
 	}
 
 	return &unionResolvedDecoder{
-		cfg:      cfg,
+		cfg:      d.cfg,
 		schema:   union,
 		types:    types,
 		decoders: decoders,
@@ -309,7 +309,7 @@ func (d *unionResolvedDecoder) Decode(ptr unsafe.Pointer, r *Reader) {
 			r.ReportError("Union", err.Error())
 			return
 		}
-		obj[name] = genericDecode(vTyp, decoderOfType(d.cfg, schema, vTyp), r)
+		obj[name] = genericDecode(vTyp, decoderOfType(newDecoderContext(d.cfg), schema, vTyp), r)
 
 		*pObj = obj
 		return
@@ -359,10 +359,10 @@ func unionResolutionName(schema Schema) string {
 	return name
 }
 
-func encoderOfResolverUnion(cfg *frozenConfig, schema Schema, typ reflect2.Type) ValEncoder {
+func encoderOfResolverUnion(e *encoderContext, schema Schema, typ reflect2.Type) ValEncoder {
 	union := schema.(*UnionSchema)
 
-	names, err := cfg.resolver.Name(typ)
+	names, err := e.cfg.resolver.Name(typ)
 	if err != nil {
 		return &errorEncoder{err: err}
 	}
@@ -382,7 +382,7 @@ func encoderOfResolverUnion(cfg *frozenConfig, schema Schema, typ reflect2.Type)
 		return &errorEncoder{err: fmt.Errorf("avro: unknown union type %s", names[0])}
 	}
 
-	encoder := encoderOfType(cfg, schema, typ)
+	encoder := encoderOfType(e, schema, typ)
 
 	return &unionResolverEncoder{
 		pos:     pos,
@@ -404,6 +404,11 @@ func (e *unionResolverEncoder) Encode(ptr unsafe.Pointer, w *Writer) {
 func getUnionSchema(schema *UnionSchema, r *Reader) (int, Schema) {
 	types := schema.Types()
 
+	// A resolved union has no index in the data, the writer type is known.
+	if schema.resolved {
+		return schema.resolvedIdx, types[schema.resolvedIdx]
+	}
+
 	idx := int(r.ReadLong())
 	if idx < 0 || idx > len(types)-1 {
 		r.ReportError("decode union type", "unknown union type")
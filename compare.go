@@ -0,0 +1,378 @@
+package avro
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Compare compares the Avro encoded values a and b of schema using the sort
+// order defined by the Avro specification, without decoding them.
+//
+// The result is 0 if a == b, -1 if a < b and +1 if a > b. Record fields are
+// compared in schema order, honoring the field order. Maps are not comparable
+// and result in an error.
+func Compare(schema Schema, a, b []byte) (int, error) {
+	return DefaultConfig.Compare(schema, a, b)
+}
+
+func (c *frozenConfig) Compare(schema Schema, a, b []byte) (int, error) {
+	cmp, err := c.comparatorOf(schema)
+	if err != nil {
+		return 0, err
+	}
+
+	ra := c.borrowReader(a)
+	defer c.returnReader(ra)
+	rb := c.borrowReader(b)
+	defer c.returnReader(rb)
+
+	res := cmp.Compare(ra, rb)
+
+	err = ra.Error
+	if err == nil {
+		err = rb.Error
+	}
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return 0, fmt.Errorf("avro: comparing: %w", err)
+	}
+	return res, nil
+}
+
+func (c *frozenConfig) comparatorOf(schema Schema) (comparator, error) {
+	fingerprint := cacheFingerprintOf(schema)
+	if cmp, ok := c.compareCache.Load(fingerprint); ok {
+		return cmp.(comparator), nil
+	}
+
+	cmp, err := comparatorOfType(map[*RecordSchema]*deferComparator{}, schema)
+	if err != nil {
+		return nil, err
+	}
+
+	if !c.config.DisableCaching {
+		c.compareCache.Store(fingerprint, cmp)
+	}
+	return cmp, nil
+}
+
+// comparator compares the next encoded values in two readers.
+type comparator interface {
+	Compare(a, b *Reader) int
+}
+
+// comparatorOfType creates a comparator, tracking the records being built
+// in seen so that recursive schemas terminate.
+func comparatorOfType(seen map[*RecordSchema]*deferComparator, schema Schema) (comparator, error) {
+	switch schema.Type() {
+	case Null:
+		return &nullComparator{}, nil
+
+	case Boolean:
+		return &boolComparator{}, nil
+
+	case Int, Enum:
+		return &intComparator{}, nil
+
+	case Long:
+		return &longComparator{}, nil
+
+	case Float:
+		return &floatComparator{}, nil
+
+	case Double:
+		return &doubleComparator{}, nil
+
+	case String, Bytes:
+		return &bytesComparator{}, nil
+
+	case Fixed:
+		return &fixedComparator{size: schema.(*FixedSchema).Size()}, nil
+
+	case Record:
+		rec := schema.(*RecordSchema)
+		if cmp, ok := seen[rec]; ok {
+			return cmp, nil
+		}
+
+		cmp := &deferComparator{}
+		seen[rec] = cmp
+		var err error
+		cmp.comparator, err = comparatorOfRecord(seen, rec)
+		if err != nil {
+			return nil, err
+		}
+		return cmp.comparator, nil
+
+	case Ref:
+		return comparatorOfType(seen, schema.(*RefSchema).Schema())
+
+	case Array:
+		items, err := comparatorOfType(seen, schema.(*ArraySchema).Items())
+		if err != nil {
+			return nil, err
+		}
+		return &arrayComparator{items: items}, nil
+
+	case Union:
+		return comparatorOfUnion(seen, schema.(*UnionSchema))
+
+	case Map:
+		return nil, errors.New("avro: map schemas are not comparable")
+
+	default:
+		return nil, fmt.Errorf("avro: schema type %s is unsupported", schema.Type())
+	}
+}
+
+// deferComparator delegates to a record comparator that is still being built.
+type deferComparator struct {
+	comparator comparator
+}
+
+func (c *deferComparator) Compare(a, b *Reader) int {
+	return c.comparator.Compare(a, b)
+}
+
+type nullComparator struct{}
+
+func (*nullComparator) Compare(_, _ *Reader) int {
+	return 0
+}
+
+type boolComparator struct{}
+
+func (*boolComparator) Compare(a, b *Reader) int {
+	x, y := a.ReadBool(), b.ReadBool()
+	switch {
+	case x == y:
+		return 0
+	case x:
+		return 1
+	default:
+		return -1
+	}
+}
+
+type intComparator struct{}
+
+func (*intComparator) Compare(a, b *Reader) int {
+	return compareInt64(int64(a.ReadInt()), int64(b.ReadInt()))
+}
+
+type longComparator struct{}
+
+func (*longComparator) Compare(a, b *Reader) int {
+	return compareInt64(a.ReadLong(), b.ReadLong())
+}
+
+type floatComparator struct{}
+
+func (*floatComparator) Compare(a, b *Reader) int {
+	return compareFloat64(float64(a.ReadFloat()), float64(b.ReadFloat()))
+}
+
+type doubleComparator struct{}
+
+func (*doubleComparator) Compare(a, b *Reader) int {
+	return compareFloat64(a.ReadDouble(), b.ReadDouble())
+}
+
+type bytesComparator struct{}
+
+func (*bytesComparator) Compare(a, b *Reader) int {
+	x := readRawBytes(a, int(a.ReadLong()))
+	y := readRawBytes(b, int(b.ReadLong()))
+	return bytes.Compare(x, y)
+}
+
+type fixedComparator struct {
+	size int
+}
+
+func (c *fixedComparator) Compare(a, b *Reader) int {
+	return bytes.Compare(readRawBytes(a, c.size), readRawBytes(b, c.size))
+}
+
+// readRawBytes returns the next size bytes of r, referencing its buffer.
+func readRawBytes(r *Reader, size int) []byte {
+	if size < 0 {
+		r.ReportError("Compare", "invalid bytes length")
+		return nil
+	}
+	if size > r.tail-r.head {
+		r.head = r.tail
+		if r.Error == nil {
+			r.Error = io.EOF
+		}
+		return nil
+	}
+
+	b := r.buf[r.head : r.head+size]
+	r.head += size
+	return b
+}
+
+type arrayComparator struct {
+	items comparator
+}
+
+func (c *arrayComparator) Compare(a, b *Reader) int {
+	var na, nb int64
+	for {
+		if na == 0 {
+			na, _ = a.ReadBlockHeader()
+		}
+		if nb == 0 {
+			nb, _ = b.ReadBlockHeader()
+		}
+		if a.Error != nil || b.Error != nil {
+			return 0
+		}
+
+		switch {
+		case na == 0 && nb == 0:
+			return 0
+		case na == 0:
+			return -1
+		case nb == 0:
+			return 1
+		}
+
+		if res := c.items.Compare(a, b); res != 0 {
+			return res
+		}
+		na--
+		nb--
+	}
+}
+
+func comparatorOfRecord(seen map[*RecordSchema]*deferComparator, rec *RecordSchema) (comparator, error) {
+	fields := make([]recordFieldComparator, 0, len(rec.Fields()))
+	for _, field := range rec.Fields() {
+		if field.action == FieldSetDefault {
+			// The field is not in the data, there is nothing to compare.
+			continue
+		}
+
+		if field.action == FieldIgnore || field.Order() == Ignore {
+			fields = append(fields, recordFieldComparator{skip: createSkipDecoder(field.Type())})
+			continue
+		}
+
+		cmp, err := comparatorOfType(seen, field.Type())
+		if err != nil {
+			return nil, fmt.Errorf("avro: field %s of record %s: %w", field.Name(), rec.FullName(), err)
+		}
+		fields = append(fields, recordFieldComparator{cmp: cmp, desc: field.Order() == Desc})
+	}
+
+	return &recordComparator{fields: fields}, nil
+}
+
+type recordFieldComparator struct {
+	cmp  comparator
+	desc bool
+	skip ValDecoder
+}
+
+type recordComparator struct {
+	fields []recordFieldComparator
+}
+
+func (c *recordComparator) Compare(a, b *Reader) int {
+	for _, field := range c.fields {
+		if field.skip != nil {
+			field.skip.Decode(nil, a)
+			field.skip.Decode(nil, b)
+			continue
+		}
+
+		res := field.cmp.Compare(a, b)
+		if a.Error != nil || b.Error != nil {
+			return 0
+		}
+		if res != 0 {
+			if field.desc {
+				return -res
+			}
+			return res
+		}
+	}
+	return 0
+}
+
+func comparatorOfUnion(seen map[*RecordSchema]*deferComparator, union *UnionSchema) (comparator, error) {
+	if union.resolved {
+		// The union is not in the data, only the resolved type is.
+		return comparatorOfType(seen, union.Types()[union.resolvedIdx])
+	}
+
+	types := make([]comparator, len(union.Types()))
+	for i, typ := range union.Types() {
+		cmp, err := comparatorOfType(seen, typ)
+		if err != nil {
+			return nil, err
+		}
+		types[i] = cmp
+	}
+	return &unionComparator{types: types}, nil
+}
+
+type unionComparator struct {
+	types []comparator
+}
+
+func (c *unionComparator) Compare(a, b *Reader) int {
+	x, y := a.ReadLong(), b.ReadLong()
+	if a.Error != nil || b.Error != nil {
+		return 0
+	}
+	if x != y {
+		return compareInt64(x, y)
+	}
+	if x < 0 || x >= int64(len(c.types)) {
+		a.ReportError("Compare", "union index out of range")
+		return 0
+	}
+
+	return c.types[x].Compare(a, b)
+}
+
+func compareInt64(x, y int64) int {
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// compareFloat64 compares x and y, ordering NaN after all other values.
+func compareFloat64(x, y float64) int {
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	case x == y:
+		return 0
+	}
+
+	xNaN, yNaN := math.IsNaN(x), math.IsNaN(y)
+	switch {
+	case xNaN && yNaN:
+		return 0
+	case xNaN:
+		return 1
+	default:
+		return -1
+	}
+}
@@ -0,0 +1,255 @@
+package avro_test
+
+import (
+	"io"
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/kjuulh/avro/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		a      any
+		b      any
+		want   int
+	}{
+		{
+			name:   "Null",
+			schema: "null",
+			a:      nil,
+			b:      nil,
+			want:   0,
+		},
+		{
+			name:   "Boolean",
+			schema: "boolean",
+			a:      false,
+			b:      true,
+			want:   -1,
+		},
+		{
+			name:   "Int Negative",
+			schema: "int",
+			a:      -1,
+			b:      1,
+			want:   -1,
+		},
+		{
+			name:   "Long",
+			schema: "long",
+			a:      int64(27),
+			b:      int64(-27),
+			want:   1,
+		},
+		{
+			name:   "Float",
+			schema: "float",
+			a:      float32(1.15),
+			b:      float32(1.15),
+			want:   0,
+		},
+		{
+			name:   "Double NaN",
+			schema: "double",
+			a:      math.NaN(),
+			b:      math.Inf(1),
+			want:   1,
+		},
+		{
+			name:   "String",
+			schema: "string",
+			a:      "abc",
+			b:      "abd",
+			want:   -1,
+		},
+		{
+			name:   "String Prefix",
+			schema: "string",
+			a:      "ab",
+			b:      "abc",
+			want:   -1,
+		},
+		{
+			name:   "Bytes",
+			schema: "bytes",
+			a:      []byte{0xff},
+			b:      []byte{0x01, 0x02},
+			want:   1,
+		},
+		{
+			name:   "Fixed",
+			schema: `{"type": "fixed", "name": "test", "size": 2}`,
+			a:      [2]byte{0x01, 0x02},
+			b:      [2]byte{0x01, 0x01},
+			want:   1,
+		},
+		{
+			name:   "Enum",
+			schema: `{"type": "enum", "name": "test", "symbols": ["b", "a"]}`,
+			a:      "a",
+			b:      "b",
+			want:   1,
+		},
+		{
+			name:   "Array",
+			schema: `{"type": "array", "items": "int"}`,
+			a:      []int{1, 2, 3},
+			b:      []int{1, 3},
+			want:   -1,
+		},
+		{
+			name:   "Array Shorter",
+			schema: `{"type": "array", "items": "int"}`,
+			a:      []int{1, 2, 3},
+			b:      []int{1, 2},
+			want:   1,
+		},
+		{
+			name:   "Union Index",
+			schema: `["null", "string"]`,
+			a:      "a",
+			b:      nil,
+			want:   1,
+		},
+		{
+			name:   "Union Value",
+			schema: `["null", "string"]`,
+			a:      "a",
+			b:      "b",
+			want:   -1,
+		},
+		{
+			name:   "Record",
+			schema: `{"type": "record", "name": "test", "fields": [{"name": "a", "type": "int"}, {"name": "b", "type": "string"}]}`,
+			a:      map[string]any{"a": 1, "b": "b"},
+			b:      map[string]any{"a": 1, "b": "a"},
+			want:   1,
+		},
+		{
+			name:   "Record Descending",
+			schema: `{"type": "record", "name": "test", "fields": [{"name": "a", "type": "int", "order": "descending"}, {"name": "b", "type": "string"}]}`,
+			a:      map[string]any{"a": 1, "b": "a"},
+			b:      map[string]any{"a": 2, "b": "a"},
+			want:   1,
+		},
+		{
+			name:   "Record Ignore",
+			schema: `{"type": "record", "name": "test", "fields": [{"name": "a", "type": {"type": "map", "values": "int"}, "order": "ignore"}, {"name": "b", "type": "string"}]}`,
+			a:      map[string]any{"a": map[string]any{"x": 1}, "b": "a"},
+			b:      map[string]any{"a": map[string]any{}, "b": "a"},
+			want:   0,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			defer ConfigTeardown()
+
+			schema := avro.MustParse(test.schema)
+			a, err := avro.Marshal(schema, test.a)
+			require.NoError(t, err)
+			b, err := avro.Marshal(schema, test.b)
+			require.NoError(t, err)
+
+			got, err := avro.Compare(schema, a, b)
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+
+			got, err = avro.Compare(schema, b, a)
+			require.NoError(t, err)
+			assert.Equal(t, -test.want, got)
+		})
+	}
+}
+
+func TestCompare_Recursive(t *testing.T) {
+	defer ConfigTeardown()
+
+	schema := avro.MustParse(`{
+	"type": "record",
+	"name": "LinkedList",
+	"fields" : [
+		{"name": "val", "type": "int"},
+		{"name": "next", "type": ["null", "LinkedList"]}
+	]
+}`)
+	a, err := avro.Marshal(schema, map[string]any{"val": 1, "next": map[string]any{"LinkedList": map[string]any{"val": 2, "next": nil}}})
+	require.NoError(t, err)
+	b, err := avro.Marshal(schema, map[string]any{"val": 1, "next": map[string]any{"LinkedList": map[string]any{"val": 3, "next": nil}}})
+	require.NoError(t, err)
+
+	got, err := avro.Compare(schema, a, b)
+
+	require.NoError(t, err)
+	assert.Equal(t, -1, got)
+}
+
+func TestCompare_Sort(t *testing.T) {
+	defer ConfigTeardown()
+
+	schema := avro.MustParse(`{"type": "record", "name": "test", "fields": [{"name": "a", "type": "long", "order": "descending"}]}`)
+	var data [][]byte
+	for _, v := range []int64{3, -1, 10, 0} {
+		b, err := avro.Marshal(schema, map[string]any{"a": v})
+		require.NoError(t, err)
+		data = append(data, b)
+	}
+
+	sort.Slice(data, func(i, j int) bool {
+		res, err := avro.Compare(schema, data[i], data[j])
+		require.NoError(t, err)
+		return res < 0
+	})
+
+	var got []int64
+	for _, b := range data {
+		var v map[string]any
+		require.NoError(t, avro.Unmarshal(schema, b, &v))
+		got = append(got, v["a"].(int64))
+	}
+	assert.Equal(t, []int64{10, 3, 0, -1}, got)
+}
+
+func TestCompare_FieldOrderNotSharedInCache(t *testing.T) {
+	defer ConfigTeardown()
+
+	api := avro.Config{}.Freeze()
+	asc := avro.MustParse(`{"type": "record", "name": "test", "fields": [{"name": "a", "type": "int"}]}`)
+	desc := avro.MustParse(`{"type": "record", "name": "test", "fields": [{"name": "a", "type": "int", "order": "descending"}]}`)
+	a, b := []byte{0x02}, []byte{0x04}
+
+	got, err := api.Compare(asc, a, b)
+	require.NoError(t, err)
+	assert.Equal(t, -1, got)
+
+	got, err = api.Compare(desc, a, b)
+	require.NoError(t, err)
+	assert.Equal(t, 1, got)
+}
+
+func TestCompare_MapError(t *testing.T) {
+	defer ConfigTeardown()
+
+	schema := avro.MustParse(`{"type": "record", "name": "test", "fields": [{"name": "a", "type": {"type": "map", "values": "int"}}]}`)
+
+	_, err := avro.Compare(schema, []byte{0x00}, []byte{0x00})
+
+	assert.Error(t, err)
+}
+
+func TestCompare_ShortData(t *testing.T) {
+	defer ConfigTeardown()
+
+	schema := avro.MustParse("string")
+
+	_, err := avro.Compare(schema, []byte{0x06, 0x66}, []byte{0x06, 0x66, 0x6f, 0x6f})
+
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
@@ -2,6 +2,7 @@ package avro
 
 import (
 	"errors"
+	"fmt"
 	"io"
 	"sync"
 
@@ -49,6 +50,11 @@ type Config struct {
 	// MaxByteSliceSize is the maximum size of `bytes` or `string` types the Reader will create, defaulting to 1MiB.
 	// If this size is exceeded, the Reader returns an error. This can be disabled by setting a negative number.
 	MaxByteSliceSize int
+
+	// ZeroCopy makes strings and bytes decoded from a byte slice, as with Unmarshal, reference
+	// the input data instead of copying it. The decoded values are only valid for as long as
+	// the input data is not modified or reused. Decoding from an io.Reader always copies.
+	ZeroCopy bool
 }
 
 // Freeze makes the configuration immutable.
@@ -56,6 +62,7 @@ func (c Config) Freeze() API {
 	api := &frozenConfig{
 		config:   c,
 		resolver: NewTypeResolver(),
+		compat:   NewSchemaCompatibility(),
 	}
 
 	api.readerPool = &sync.Pool{
@@ -98,6 +105,36 @@ type API interface {
 	// NewDecoder returns a new decoder that reads from reader r using schema.
 	NewDecoder(schema Schema, r io.Reader) *Decoder
 
+	// UnmarshalWithWriterSchema parses the Avro encoded data written with the writer schema,
+	// resolving it into the reader schema, and stores the result in the value pointed to by v.
+	UnmarshalWithWriterSchema(reader, writer Schema, data []byte, v any) error
+
+	// NewDecoderWithSchemas returns a new decoder that reads data written with the writer schema
+	// from r, resolving it into the reader schema.
+	NewDecoderWithSchemas(reader, writer Schema, r io.Reader) (*Decoder, error)
+
+	// MarshalJSONEncoding returns the Avro JSON encoding of v.
+	MarshalJSONEncoding(schema Schema, v any) ([]byte, error)
+
+	// UnmarshalJSONEncoding parses the Avro JSON encoded data and stores the result in the value pointed to by v.
+	// If v is nil or not a pointer, UnmarshalJSONEncoding returns an error.
+	UnmarshalJSONEncoding(schema Schema, data []byte, v any) error
+
+	// NewJSONEncoder returns a new encoder that writes the Avro JSON encoding to w using schema.
+	NewJSONEncoder(schema Schema, w io.Writer) *JSONEncoder
+
+	// NewJSONDecoder returns a new decoder that reads the Avro JSON encoding from reader r using schema.
+	NewJSONDecoder(schema Schema, r io.Reader) *JSONDecoder
+
+	// ExtractRaw returns the Avro encoding of the value at path in the Avro encoded data.
+	ExtractRaw(schema Schema, data []byte, path string) ([]byte, error)
+
+	// Extract decodes the value at path in the Avro encoded data into the value pointed to by v.
+	Extract(schema Schema, data []byte, path string, v any) error
+
+	// Compare compares the Avro encoded values a and b of schema using the Avro sort order.
+	Compare(schema Schema, a, b []byte) (int, error)
+
 	// DecoderOf returns the value decoder for a given schema and type.
 	DecoderOf(schema Schema, typ reflect2.Type) ValDecoder
 
@@ -111,13 +148,18 @@ type API interface {
 type frozenConfig struct {
 	config Config
 
-	decoderCache sync.Map // map[cacheKey]ValDecoder
-	encoderCache sync.Map // map[cacheKey]ValEncoder
+	decoderCache  sync.Map // map[cacheKey]ValDecoder
+	encoderCache  sync.Map // map[cacheKey]ValEncoder
+	resolvedCache sync.Map // map[compatKey]Schema
+	extractCache  sync.Map // map[extractKey]*extractPlan
+	compareCache  sync.Map // map[[32]byte]comparator
+	skipCache     sync.Map // map[[32]byte]ValDecoder
 
 	readerPool *sync.Pool
 	writerPool *sync.Pool
 
 	resolver *TypeResolver
+	compat   *SchemaCompatibility
 }
 
 func (c *frozenConfig) Marshal(schema Schema, v any) ([]byte, error) {
@@ -152,7 +194,13 @@ func (c *frozenConfig) returnWriter(writer *Writer) {
 
 func (c *frozenConfig) Unmarshal(schema Schema, data []byte, v any) error {
 	reader := c.borrowReader(data)
+	reader.zeroCopy = c.config.ZeroCopy
+
+	return c.unmarshal(reader, schema, v)
+}
 
+// unmarshal decodes a value from a borrowed reader, returning the reader to the pool.
+func (c *frozenConfig) unmarshal(reader *Reader, schema Schema, v any) error {
 	reader.ReadVal(schema, v)
 	err := reader.Error
 	c.returnReader(reader)
@@ -164,9 +212,12 @@ func (c *frozenConfig) Unmarshal(schema Schema, data []byte, v any) error {
 	return err
 }
 
+// borrowReader returns a pooled reader of data. The data is not caller owned,
+// so decoded values never reference it.
 func (c *frozenConfig) borrowReader(data []byte) *Reader {
 	reader := c.readerPool.Get().(*Reader)
 	reader.Reset(data)
+	reader.zeroCopy = false
 	return reader
 }
 
@@ -194,6 +245,58 @@ func (c *frozenConfig) NewDecoder(schema Schema, r io.Reader) *Decoder {
 	}
 }
 
+func (c *frozenConfig) UnmarshalWithWriterSchema(reader, writer Schema, data []byte, v any) error {
+	schema, err := c.resolveSchema(reader, writer)
+	if err != nil {
+		return err
+	}
+
+	return c.Unmarshal(schema, data, v)
+}
+
+func (c *frozenConfig) NewDecoderWithSchemas(reader, writer Schema, r io.Reader) (*Decoder, error) {
+	schema, err := c.resolveSchema(reader, writer)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.NewDecoder(schema, r), nil
+}
+
+func (c *frozenConfig) resolveSchema(reader, writer Schema) (Schema, error) {
+	// The canonical fingerprints drop defaults and aliases, which the
+	// resolved schema depends on, so the full schemas are used as the key.
+	key, keyErr := resolvedKeyOf(reader, writer)
+	if keyErr == nil {
+		if schema, ok := c.resolvedCache.Load(key); ok {
+			return schema.(Schema), nil
+		}
+	}
+
+	schema, err := c.compat.Resolve(reader, writer)
+	if err != nil {
+		return nil, fmt.Errorf("avro: unable to resolve writer schema: %w", err)
+	}
+
+	if !c.config.DisableCaching && keyErr == nil {
+		c.resolvedCache.Store(key, schema)
+	}
+	return schema, nil
+}
+
+// resolvedKeyOf returns the cache key of the resolution of writer into reader.
+func resolvedKeyOf(reader, writer Schema) (compatKey, error) {
+	r, err := fullFingerprintOf(reader)
+	if err != nil {
+		return compatKey{}, err
+	}
+	w, err := fullFingerprintOf(writer)
+	if err != nil {
+		return compatKey{}, err
+	}
+	return compatKey{reader: r, writer: w}, nil
+}
+
 func (c *frozenConfig) Register(name string, obj any) {
 	c.resolver.Register(name, obj)
 }
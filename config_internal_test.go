@@ -5,6 +5,7 @@ import (
 
 	"github.com/modern-go/reflect2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestConfig_Freeze(t *testing.T) {
@@ -198,3 +199,61 @@ func TestConfig_DisableCache_DoesNotReuseEncoders(t *testing.T) {
 
 	assert.NotSame(t, enc1, enc2)
 }
+
+func TestConfig_ReusesResolvedSchemas(t *testing.T) {
+	api := Config{}.Freeze()
+	cfg := api.(*frozenConfig)
+
+	reader := MustParse(`{"type": "array", "items": "long"}`)
+	writer := MustParse(`{"type": "array", "items": "int"}`)
+
+	schema1, err := cfg.resolveSchema(reader, writer)
+	require.NoError(t, err)
+	schema2, err := cfg.resolveSchema(reader, writer)
+	require.NoError(t, err)
+
+	assert.Same(t, schema1, schema2)
+}
+
+func TestConfig_DisableCachingResolvedSchemas(t *testing.T) {
+	api := Config{DisableCaching: true}.Freeze()
+	cfg := api.(*frozenConfig)
+
+	reader := MustParse(`{"type": "array", "items": "long"}`)
+	writer := MustParse(`{"type": "array", "items": "int"}`)
+
+	schema1, err := cfg.resolveSchema(reader, writer)
+	require.NoError(t, err)
+	schema2, err := cfg.resolveSchema(reader, writer)
+	require.NoError(t, err)
+
+	assert.NotSame(t, schema1, schema2)
+}
+
+func TestConfig_ReusesExtractPlans(t *testing.T) {
+	api := Config{}.Freeze()
+	cfg := api.(*frozenConfig)
+
+	schema := MustParse(`{"type": "record", "name": "test", "fields": [{"name": "a", "type": "int"}, {"name": "b", "type": "string"}]}`)
+
+	plan1, err := cfg.extractPlanOf(schema, "b")
+	require.NoError(t, err)
+	plan2, err := cfg.extractPlanOf(schema, "b")
+	require.NoError(t, err)
+
+	assert.Same(t, plan1, plan2)
+}
+
+func TestConfig_DisableCachingExtractPlans(t *testing.T) {
+	api := Config{DisableCaching: true}.Freeze()
+	cfg := api.(*frozenConfig)
+
+	schema := MustParse(`{"type": "record", "name": "test", "fields": [{"name": "a", "type": "int"}, {"name": "b", "type": "string"}]}`)
+
+	plan1, err := cfg.extractPlanOf(schema, "b")
+	require.NoError(t, err)
+	plan2, err := cfg.extractPlanOf(schema, "b")
+	require.NoError(t, err)
+
+	assert.NotSame(t, plan1, plan2)
+}
@@ -26,6 +26,12 @@ func NewDecoderForSchema(schema Schema, reader io.Reader) *Decoder {
 	return DefaultConfig.NewDecoder(schema, reader)
 }
 
+// NewDecoderWithSchemas returns a new decoder that reads data written with the writer schema
+// from r, resolving it into the reader schema.
+func NewDecoderWithSchemas(reader, writer Schema, r io.Reader) (*Decoder, error) {
+	return DefaultConfig.NewDecoderWithSchemas(reader, writer, r)
+}
+
 // Decode reads the next Avro encoded value from its input and stores it in the value pointed to by v.
 func (d *Decoder) Decode(obj any) error {
 	if d.r.head == d.r.tail && d.r.reader != nil {
@@ -48,3 +54,9 @@ func (d *Decoder) Decode(obj any) error {
 func Unmarshal(schema Schema, data []byte, v any) error {
 	return DefaultConfig.Unmarshal(schema, data, v)
 }
+
+// UnmarshalWithWriterSchema parses the Avro encoded data written with the writer schema,
+// resolving it into the reader schema, and stores the result in the value pointed to by v.
+func UnmarshalWithWriterSchema(reader, writer Schema, data []byte, v any) error {
+	return DefaultConfig.UnmarshalWithWriterSchema(reader, writer, data, v)
+}
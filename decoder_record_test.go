@@ -393,3 +393,123 @@ func TestDecoder_RefStruct(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, want, got)
 }
+
+var (
+	testLinkedListSchema = `{
+	"type": "record",
+	"name": "LinkedList",
+	"fields" : [
+		{"name": "value", "type": "long"},
+		{"name": "next", "type": ["null", "LinkedList"], "default": null}
+	]
+}`
+	testTreeSchema = `{
+	"type": "record",
+	"name": "Tree",
+	"fields" : [
+		{"name": "value", "type": "long"},
+		{"name": "children", "type": {"type": "array", "items": "Tree"}}
+	]
+}`
+	testMapTreeSchema = `{
+	"type": "record",
+	"name": "Tree",
+	"fields" : [
+		{"name": "value", "type": "long"},
+		{"name": "children", "type": {"type": "map", "values": "Tree"}}
+	]
+}`
+)
+
+func TestDecoder_RecursiveStructPtr(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0x02, 0x02, 0x04, 0x00}
+	dec, err := avro.NewDecoder(testLinkedListSchema, bytes.NewReader(data))
+	require.NoError(t, err)
+
+	var got TestLinkedList
+	err = dec.Decode(&got)
+
+	require.NoError(t, err)
+	assert.Equal(t, TestLinkedList{Value: 1, Next: &TestLinkedList{Value: 2}}, got)
+}
+
+func TestDecoder_RecursiveStructSlice(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0x02, 0x02, 0x04, 0x00, 0x00}
+	dec, err := avro.NewDecoder(testTreeSchema, bytes.NewReader(data))
+	require.NoError(t, err)
+
+	var got TestTree
+	err = dec.Decode(&got)
+
+	require.NoError(t, err)
+	assert.Equal(t, TestTree{Value: 1, Children: []TestTree{{Value: 2}}}, got)
+}
+
+func TestDecoder_RecursiveStructMap(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0x02, 0x02, 0x02, 0x61, 0x04, 0x00, 0x00}
+	dec, err := avro.NewDecoder(testMapTreeSchema, bytes.NewReader(data))
+	require.NoError(t, err)
+
+	var got TestMapTree
+	err = dec.Decode(&got)
+
+	require.NoError(t, err)
+	want := TestMapTree{
+		Value:    1,
+		Children: map[string]TestMapTree{"a": {Value: 2, Children: map[string]TestMapTree{}}},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestDecoder_RecursiveMap(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0x02, 0x02, 0x04, 0x00}
+	dec, err := avro.NewDecoder(testLinkedListSchema, bytes.NewReader(data))
+	require.NoError(t, err)
+
+	var got map[string]any
+	err = dec.Decode(&got)
+
+	require.NoError(t, err)
+	want := map[string]any{
+		"value": int64(1),
+		"next":  map[string]any{"LinkedList": map[string]any{"value": int64(2), "next": nil}},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestDecoder_RecursiveSkip(t *testing.T) {
+	defer ConfigTeardown()
+
+	data := []byte{0x02, 0x02, 0x04, 0x00, 0x00, 0x06, 0x66, 0x6f, 0x6f}
+	schema := `{
+	"type": "record",
+	"name": "test",
+	"fields" : [
+		{"name": "tree", "type": {
+			"type": "record",
+			"name": "Tree",
+			"fields" : [
+				{"name": "value", "type": "long"},
+				{"name": "children", "type": {"type": "array", "items": "Tree"}}
+			]}
+		},
+		{"name": "b", "type": "string"}
+	]
+}`
+	dec, err := avro.NewDecoder(schema, bytes.NewReader(data))
+	require.NoError(t, err)
+
+	var got TestPartialRecord
+	err = dec.Decode(&got)
+
+	require.NoError(t, err)
+	assert.Equal(t, TestPartialRecord{B: "foo"}, got)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/kjuulh/avro/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewDecoder_SchemaError(t *testing.T) {
@@ -112,3 +113,171 @@ func TestUnmarshal_NilPtr(t *testing.T) {
 
 	assert.Error(t, err)
 }
+
+func TestUnmarshalWithWriterSchema(t *testing.T) {
+	defer ConfigTeardown()
+
+	writer := avro.MustParse(`{
+	"type": "record",
+	"name": "test",
+	"fields" : [
+		{"name": "b", "type": "string"},
+		{"name": "x", "type": "long"},
+		{"name": "a", "type": "int"},
+		{"name": "old", "type": "string"},
+		{"name": "e", "type": {"type": "enum", "name": "en", "symbols": ["foo", "bar", "baz"]}},
+		{"name": "u", "type": "string"}
+	]
+}`)
+	reader := avro.MustParse(`{
+	"type": "record",
+	"name": "test",
+	"fields" : [
+		{"name": "a", "type": "long"},
+		{"name": "b", "type": "string"},
+		{"name": "c", "type": "string", "default": "def"},
+		{"name": "new", "aliases": ["old"], "type": "string"},
+		{"name": "e", "type": {"type": "enum", "name": "en", "symbols": ["foo", "bar"], "default": "foo"}},
+		{"name": "u", "type": ["null", "string"]}
+	]
+}`)
+	data, err := avro.Marshal(writer, map[string]any{
+		"b": "foo", "x": int64(5), "a": 27, "old": "alias", "e": "baz", "u": "union",
+	})
+	require.NoError(t, err)
+
+	type resolvedRecord struct {
+		A   int64   `avro:"a"`
+		B   string  `avro:"b"`
+		C   string  `avro:"c"`
+		New string  `avro:"new"`
+		E   string  `avro:"e"`
+		U   *string `avro:"u"`
+	}
+	var got resolvedRecord
+	err = avro.UnmarshalWithWriterSchema(reader, writer, data, &got)
+
+	require.NoError(t, err)
+	u := "union"
+	want := resolvedRecord{A: 27, B: "foo", C: "def", New: "alias", E: "foo", U: &u}
+	assert.Equal(t, want, got)
+}
+
+func TestUnmarshalWithWriterSchema_Incompatible(t *testing.T) {
+	defer ConfigTeardown()
+
+	writer := avro.MustParse("string")
+	reader := avro.MustParse("int")
+
+	var i int
+	err := avro.UnmarshalWithWriterSchema(reader, writer, []byte{0x02, 0x61}, &i)
+
+	assert.Error(t, err)
+}
+
+func TestNewDecoderWithSchemas(t *testing.T) {
+	defer ConfigTeardown()
+
+	writer := avro.MustParse(`{"type": "array", "items": "int"}`)
+	reader := avro.MustParse(`{"type": "array", "items": "double"}`)
+	data := []byte{0x04, 0x02, 0x04, 0x00, 0x02, 0x06, 0x00}
+
+	dec, err := avro.NewDecoderWithSchemas(reader, writer, bytes.NewReader(data))
+	require.NoError(t, err)
+
+	var got []float64
+	require.NoError(t, dec.Decode(&got))
+	assert.Equal(t, []float64{1, 2}, got)
+	require.NoError(t, dec.Decode(&got))
+	assert.Equal(t, []float64{3}, got)
+}
+
+func TestNewDecoderWithSchemas_Incompatible(t *testing.T) {
+	defer ConfigTeardown()
+
+	writer := avro.MustParse("string")
+	reader := avro.MustParse("int")
+
+	_, err := avro.NewDecoderWithSchemas(reader, writer, bytes.NewReader(nil))
+
+	assert.Error(t, err)
+}
+
+func TestUnmarshalWithWriterSchema_DoesNotShareReaderDecoder(t *testing.T) {
+	defer ConfigTeardown()
+
+	writer := avro.MustParse(`{"type": "record", "name": "test", "fields": [{"name": "a", "type": "int"}]}`)
+	reader := avro.MustParse(`{"type": "record", "name": "test", "fields": [{"name": "a", "type": "long"}]}`)
+
+	var got TestRecord
+	err := avro.Unmarshal(reader, []byte{0x36, 0x00}, &got)
+	require.NoError(t, err)
+
+	err = avro.UnmarshalWithWriterSchema(reader, writer, []byte{0x38}, &got)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(28), got.A)
+}
+
+func TestUnmarshalWithWriterSchema_ReaderDefaults(t *testing.T) {
+	defer ConfigTeardown()
+
+	writer := avro.MustParse(`{"type": "record", "name": "test", "fields": [{"name": "a", "type": "long"}]}`)
+	reader1 := avro.MustParse(`{"type": "record", "name": "test", "fields": [
+		{"name": "a", "type": "long"},
+		{"name": "b", "type": "string", "default": "one"}
+	]}`)
+	reader2 := avro.MustParse(`{"type": "record", "name": "test", "fields": [
+		{"name": "a", "type": "long"},
+		{"name": "b", "type": "string", "default": "two"}
+	]}`)
+
+	var got1, got2 TestRecord
+	err := avro.UnmarshalWithWriterSchema(reader1, writer, []byte{0x36}, &got1)
+	require.NoError(t, err)
+	err = avro.UnmarshalWithWriterSchema(reader2, writer, []byte{0x36}, &got2)
+	require.NoError(t, err)
+
+	assert.Equal(t, TestRecord{A: 27, B: "one"}, got1)
+	assert.Equal(t, TestRecord{A: 27, B: "two"}, got2)
+}
+
+func TestUnmarshal_ZeroCopy(t *testing.T) {
+	defer ConfigTeardown()
+
+	api := avro.Config{ZeroCopy: true}.Freeze()
+	schema := avro.MustParse(`{"type": "record", "name": "test", "fields": [{"name": "a", "type": "long"}, {"name": "b", "type": "string"}]}`)
+	data := []byte{0x36, 0x06, 0x66, 0x6f, 0x6f}
+
+	var got TestRecord
+	err := api.Unmarshal(schema, data, &got)
+
+	require.NoError(t, err)
+	assert.Equal(t, TestRecord{A: 27, B: "foo"}, got)
+	data[2] = 0x62
+	assert.Equal(t, "boo", got.B)
+}
+
+func TestUnmarshal_ZeroCopyDoesNotReferenceDefaults(t *testing.T) {
+	defer ConfigTeardown()
+
+	api := avro.Config{ZeroCopy: true}.Freeze()
+	writer := avro.MustParse(`{"type": "record", "name": "test", "fields": [{"name": "a", "type": "long"}]}`)
+	reader := avro.MustParse(`{"type": "record", "name": "test", "fields": [
+		{"name": "a", "type": "long"},
+		{"name": "b", "type": "bytes", "default": "xyz"}
+	]}`)
+	type record struct {
+		A int64  `avro:"a"`
+		B []byte `avro:"b"`
+	}
+
+	var first, second record
+	err := api.UnmarshalWithWriterSchema(reader, writer, []byte{0x36}, &first)
+	require.NoError(t, err)
+	first.B[0] = 'Z'
+	err = api.UnmarshalWithWriterSchema(reader, writer, []byte{0x36}, &second)
+
+	require.NoError(t, err)
+	assert.Equal(t, []byte("xyz"), second.B)
+}
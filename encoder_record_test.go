@@ -622,3 +622,94 @@ func TestEncoder_RefStruct(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, []byte{0x36, 0x06, 0x66, 0x6f, 0x6f, 0x36, 0x06, 0x66, 0x6f, 0x6f}, buf.Bytes())
 }
+
+func TestEncoder_RecursiveStructPtr(t *testing.T) {
+	defer ConfigTeardown()
+
+	schema := `{
+	"type": "record",
+	"name": "LinkedList",
+	"fields" : [
+		{"name": "value", "type": "long"},
+		{"name": "next", "type": ["null", "LinkedList"], "default": null}
+	]
+}`
+	obj := TestLinkedList{Value: 1, Next: &TestLinkedList{Value: 2}}
+	buf := &bytes.Buffer{}
+	enc, err := avro.NewEncoder(schema, buf)
+	require.NoError(t, err)
+
+	err = enc.Encode(obj)
+
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x02, 0x02, 0x04, 0x00}, buf.Bytes())
+}
+
+func TestEncoder_RecursiveStructSlice(t *testing.T) {
+	defer ConfigTeardown()
+
+	schema := `{
+	"type": "record",
+	"name": "Tree",
+	"fields" : [
+		{"name": "value", "type": "long"},
+		{"name": "children", "type": {"type": "array", "items": "Tree"}}
+	]
+}`
+	obj := TestTree{Value: 1, Children: []TestTree{{Value: 2}}}
+	buf := &bytes.Buffer{}
+	enc, err := avro.NewEncoder(schema, buf)
+	require.NoError(t, err)
+
+	err = enc.Encode(obj)
+
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x02, 0x01, 0x04, 0x04, 0x00, 0x00}, buf.Bytes())
+}
+
+func TestEncoder_RecursiveStructMap(t *testing.T) {
+	defer ConfigTeardown()
+
+	schema := `{
+	"type": "record",
+	"name": "Tree",
+	"fields" : [
+		{"name": "value", "type": "long"},
+		{"name": "children", "type": {"type": "map", "values": "Tree"}}
+	]
+}`
+	obj := TestMapTree{Value: 1, Children: map[string]TestMapTree{"a": {Value: 2}}}
+	buf := &bytes.Buffer{}
+	enc, err := avro.NewEncoder(schema, buf)
+	require.NoError(t, err)
+
+	err = enc.Encode(obj)
+
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x02, 0x01, 0x08, 0x02, 0x61, 0x04, 0x00, 0x00}, buf.Bytes())
+}
+
+func TestEncoder_RecursiveMap(t *testing.T) {
+	defer ConfigTeardown()
+
+	schema := `{
+	"type": "record",
+	"name": "LinkedList",
+	"fields" : [
+		{"name": "value", "type": "long"},
+		{"name": "next", "type": ["null", "LinkedList"], "default": null}
+	]
+}`
+	obj := map[string]any{
+		"value": int64(1),
+		"next":  map[string]any{"LinkedList": map[string]any{"value": int64(2), "next": nil}},
+	}
+	buf := &bytes.Buffer{}
+	enc, err := avro.NewEncoder(schema, buf)
+	require.NoError(t, err)
+
+	err = enc.Encode(obj)
+
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x02, 0x02, 0x04, 0x00}, buf.Bytes())
+}
@@ -0,0 +1,206 @@
+package avro
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ExtractRaw returns the Avro encoding of the value at path in the Avro encoded data.
+//
+// A path is a dot separated list of record field names, e.g. "header.tenant_id".
+// The returned bytes reference data.
+func ExtractRaw(schema Schema, data []byte, path string) ([]byte, error) {
+	return DefaultConfig.ExtractRaw(schema, data, path)
+}
+
+// Extract decodes the value at path in the Avro encoded data into the value pointed to by v.
+//
+// A path is a dot separated list of record field names, e.g. "header.tenant_id".
+func Extract(schema Schema, data []byte, path string, v any) error {
+	return DefaultConfig.Extract(schema, data, path, v)
+}
+
+func (c *frozenConfig) ExtractRaw(schema Schema, data []byte, path string) ([]byte, error) {
+	plan, err := c.extractPlanOf(schema, path)
+	if err != nil {
+		return nil, err
+	}
+
+	r := c.borrowReader(data)
+	defer c.returnReader(r)
+
+	leaf := plan.locate(r)
+	if r.Error != nil {
+		return nil, extractError(path, r.Error)
+	}
+
+	start := r.head
+	leaf.skip.Decode(nil, r)
+	if r.Error != nil {
+		return nil, extractError(path, r.Error)
+	}
+	return data[start:r.head:r.head], nil
+}
+
+func (c *frozenConfig) Extract(schema Schema, data []byte, path string, v any) error {
+	plan, err := c.extractPlanOf(schema, path)
+	if err != nil {
+		return err
+	}
+
+	r := c.borrowReader(data)
+	defer c.returnReader(r)
+
+	leaf := plan.locate(r)
+	if r.Error != nil {
+		return extractError(path, r.Error)
+	}
+
+	r.ReadVal(leaf.schema, v)
+	if r.Error != nil {
+		return extractError(path, r.Error)
+	}
+	return nil
+}
+
+func extractError(path string, err error) error {
+	if errors.Is(err, io.EOF) {
+		err = io.ErrUnexpectedEOF
+	}
+	return fmt.Errorf("avro: extracting %q: %w", path, err)
+}
+
+type extractKey struct {
+	fingerprint [32]byte
+	path        string
+}
+
+func (c *frozenConfig) extractPlanOf(schema Schema, path string) (*extractPlan, error) {
+	key := extractKey{fingerprint: cacheFingerprintOf(schema), path: path}
+	if plan, ok := c.extractCache.Load(key); ok {
+		return plan.(*extractPlan), nil
+	}
+
+	names := strings.Split(path, ".")
+	for _, name := range names {
+		if name == "" {
+			return nil, fmt.Errorf("avro: invalid extract path %q", path)
+		}
+	}
+
+	plan, err := newExtractPlan(schema, names, path)
+	if err != nil {
+		return nil, err
+	}
+
+	if !c.config.DisableCaching {
+		c.extractCache.Store(key, plan)
+	}
+	return plan, nil
+}
+
+// extractPlan describes how to reach a value in the encoded data.
+//
+// The decoders in skips are run first. The plan then continues with
+// next for a record field, or with the plan of the encoded branch for a union.
+// A plan with neither is the value itself.
+type extractPlan struct {
+	skips []ValDecoder
+
+	next *extractPlan
+
+	union    *UnionSchema
+	branches []*extractPlan
+
+	schema Schema
+	skip   ValDecoder
+}
+
+func newExtractPlan(schema Schema, names []string, path string) (*extractPlan, error) {
+	if schema.Type() == Ref {
+		schema = schema.(*RefSchema).Schema()
+	}
+
+	if len(names) == 0 {
+		return &extractPlan{schema: schema, skip: createSkipDecoder(schema)}, nil
+	}
+
+	switch schema.Type() {
+	case Record:
+		rec := schema.(*RecordSchema)
+		skips := make([]ValDecoder, 0, len(rec.Fields()))
+		for _, field := range rec.Fields() {
+			if field.action == FieldSetDefault {
+				if field.Name() == names[0] {
+					return nil, fmt.Errorf("avro: field %q of path %q is not in the encoded data", names[0], path)
+				}
+				continue
+			}
+
+			if field.Name() != names[0] {
+				skips = append(skips, createSkipDecoder(field.Type()))
+				continue
+			}
+
+			next, err := newExtractPlan(field.Type(), names[1:], path)
+			if err != nil {
+				return nil, err
+			}
+			return &extractPlan{skips: skips, next: next}, nil
+		}
+		return nil, fmt.Errorf("avro: field %q of path %q not found in record %s", names[0], path, rec.FullName())
+
+	case Union:
+		union := schema.(*UnionSchema)
+		branches := make([]*extractPlan, len(union.Types()))
+		var found bool
+		for i, typ := range union.Types() {
+			plan, err := newExtractPlan(typ, names, path)
+			if err != nil {
+				continue
+			}
+			branches[i] = plan
+			found = true
+		}
+		if !found {
+			return nil, fmt.Errorf("avro: field %q of path %q not found in any union type", names[0], path)
+		}
+		return &extractPlan{union: union, branches: branches}, nil
+
+	default:
+		return nil, fmt.Errorf("avro: field %q of path %q not found in %s", names[0], path, schema.Type())
+	}
+}
+
+// locate advances r to the value and returns its plan.
+func (p *extractPlan) locate(r *Reader) *extractPlan {
+	for {
+		for _, skip := range p.skips {
+			skip.Decode(nil, r)
+		}
+		if r.Error != nil {
+			return nil
+		}
+
+		switch {
+		case p.next != nil:
+			p = p.next
+
+		case p.union != nil:
+			idx, _ := getUnionSchema(p.union, r)
+			if r.Error != nil {
+				return nil
+			}
+			if p.branches[idx] == nil {
+				r.ReportError("Extract", "path not present in encoded union type")
+				return nil
+			}
+			p = p.branches[idx]
+
+		default:
+			return p
+		}
+	}
+}
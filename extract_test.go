@@ -0,0 +1,157 @@
+package avro_test
+
+import (
+	"io"
+	"testing"
+
+	"github.com/kjuulh/avro/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testExtractSchema = `{
+	"type": "record",
+	"name": "event",
+	"fields" : [
+		{"name": "id", "type": "long"},
+		{"name": "tags", "type": {"type": "array", "items": "string"}},
+		{"name": "header", "type": {
+			"type": "record",
+			"name": "header",
+			"fields": [
+				{"name": "source", "type": "string"},
+				{"name": "tenant_id", "type": "string"}
+			]
+		}},
+		{"name": "parent", "type": ["null", "header"]},
+		{"name": "count", "type": "int"}
+	]
+}`
+
+func testExtractData(t *testing.T, parent any) (avro.Schema, []byte) {
+	t.Helper()
+
+	schema := avro.MustParse(testExtractSchema)
+	data, err := avro.Marshal(schema, map[string]any{
+		"id":     int64(1),
+		"tags":   []any{"a", "b"},
+		"header": map[string]any{"source": "foo", "tenant_id": "bar"},
+		"parent": parent,
+		"count":  27,
+	})
+	require.NoError(t, err)
+	return schema, data
+}
+
+func TestExtractRaw(t *testing.T) {
+	defer ConfigTeardown()
+
+	schema, data := testExtractData(t, nil)
+
+	got, err := avro.ExtractRaw(schema, data, "header.tenant_id")
+
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x06, 0x62, 0x61, 0x72}, got)
+}
+
+func TestExtractRaw_Record(t *testing.T) {
+	defer ConfigTeardown()
+
+	schema, data := testExtractData(t, nil)
+
+	got, err := avro.ExtractRaw(schema, data, "header")
+
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x06, 0x66, 0x6f, 0x6f, 0x06, 0x62, 0x61, 0x72}, got)
+}
+
+func TestExtract(t *testing.T) {
+	defer ConfigTeardown()
+
+	schema, data := testExtractData(t, nil)
+
+	var got string
+	err := avro.Extract(schema, data, "header.tenant_id", &got)
+	require.NoError(t, err)
+	var count int
+	err = avro.Extract(schema, data, "count", &count)
+	require.NoError(t, err)
+
+	assert.Equal(t, "bar", got)
+	assert.Equal(t, 27, count)
+}
+
+func TestExtract_Union(t *testing.T) {
+	defer ConfigTeardown()
+
+	schema, data := testExtractData(t, map[string]any{"header": map[string]any{"source": "baz", "tenant_id": "qux"}})
+
+	var got string
+	err := avro.Extract(schema, data, "parent.tenant_id", &got)
+
+	require.NoError(t, err)
+	assert.Equal(t, "qux", got)
+}
+
+func TestExtract_UnionNull(t *testing.T) {
+	defer ConfigTeardown()
+
+	schema, data := testExtractData(t, nil)
+
+	var got string
+	err := avro.Extract(schema, data, "parent.tenant_id", &got)
+
+	assert.Error(t, err)
+}
+
+func TestExtract_ShortData(t *testing.T) {
+	defer ConfigTeardown()
+
+	schema, data := testExtractData(t, nil)
+
+	var got int
+	err := avro.Extract(schema, data[:len(data)-1], "count", &got)
+
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
+
+func TestExtract_PathErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+	}{
+		{
+			name: "empty",
+			path: "",
+		},
+		{
+			name: "empty segment",
+			path: "header..source",
+		},
+		{
+			name: "unknown field",
+			path: "header.name",
+		},
+		{
+			name: "beneath primitive",
+			path: "id.value",
+		},
+		{
+			name: "beneath array",
+			path: "tags.value",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			defer ConfigTeardown()
+
+			schema := avro.MustParse(testExtractSchema)
+
+			_, err := avro.ExtractRaw(schema, []byte{}, test.path)
+
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,939 @@
+package avro
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// idlProtocol is a protocol parsed from IDL, with its types and messages
+// still in their JSON form.
+type idlProtocol struct {
+	name      string
+	namespace string
+	doc       string
+	props     map[string]any
+	types     []idlSchema
+	messages  []idlMessage
+}
+
+type idlSchema struct {
+	namespace string
+	schema    any
+}
+
+type idlMessage struct {
+	name      string
+	namespace string
+	message   map[string]any
+}
+
+func parseIDL(idl, dir string, imported map[string]struct{}) (*Protocol, error) {
+	p, err := parseIDLProtocol(idl, dir, imported)
+	if err != nil {
+		return nil, err
+	}
+
+	cache := &SchemaCache{}
+	seen := seenCache{}
+
+	types := make([]NamedSchema, 0, len(p.types))
+	for _, typ := range p.types {
+		schema, err := parseType(typ.namespace, typ.schema, seen, cache)
+		if err != nil {
+			return nil, err
+		}
+
+		namedSchema, ok := schema.(NamedSchema)
+		if !ok {
+			return nil, errors.New("avro: protocol types must be named schemas")
+		}
+		types = append(types, namedSchema)
+	}
+
+	messages := make(map[string]*Message, len(p.messages))
+	for _, msg := range p.messages {
+		message, err := parseMessage(msg.namespace, msg.message, seen, cache)
+		if err != nil {
+			return nil, err
+		}
+		messages[msg.name] = message
+	}
+
+	return NewProtocol(p.name, p.namespace, types, messages, WithProtoDoc(p.doc), WithProtoProps(p.props))
+}
+
+func parseIDLProtocol(idl, dir string, imported map[string]struct{}) (*idlProtocol, error) {
+	p := &idlParser{
+		lex:      &idlLexer{src: idl, line: 1},
+		dir:      dir,
+		imported: imported,
+	}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+
+	proto, err := p.parseProtocol()
+	if err != nil {
+		return nil, fmt.Errorf("avro: idl: line %d: %w", p.tok.line, err)
+	}
+	return proto, nil
+}
+
+type idlParser struct {
+	lex *idlLexer
+	tok idlToken
+
+	dir      string
+	imported map[string]struct{}
+}
+
+func (p *idlParser) next() error {
+	tok, err := p.lex.next()
+	if err != nil {
+		p.tok.line = p.lex.line
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+// keyword determines if the current token is the unquoted identifier kw.
+func (p *idlParser) keyword(kw string) bool {
+	return p.tok.kind == idlIdent && !p.tok.quoted && p.tok.val == kw
+}
+
+func (p *idlParser) punct(c string) bool {
+	return p.tok.kind == idlPunct && p.tok.val == c
+}
+
+func (p *idlParser) expectKeyword(kw string) error {
+	if !p.keyword(kw) {
+		return p.unexpected(strconv.Quote(kw))
+	}
+	return p.next()
+}
+
+func (p *idlParser) expectPunct(c string) error {
+	if !p.punct(c) {
+		return p.unexpected(strconv.Quote(c))
+	}
+	return p.next()
+}
+
+func (p *idlParser) expectIdent() (string, error) {
+	if p.tok.kind != idlIdent {
+		return "", p.unexpected("identifier")
+	}
+	name := p.tok.val
+	return name, p.next()
+}
+
+func (p *idlParser) unexpected(want string) error {
+	if p.tok.kind == idlEOF {
+		return fmt.Errorf("expected %s, got end of input", want)
+	}
+	return fmt.Errorf("expected %s, got %q", want, p.tok.val)
+}
+
+// json reads the JSON value following the current token.
+func (p *idlParser) json() (any, error) {
+	v, err := p.lex.json()
+	if err != nil {
+		return nil, err
+	}
+	return v, p.next()
+}
+
+func (p *idlParser) parseAnnotations() (map[string]any, error) {
+	props := map[string]any{}
+	for p.tok.kind == idlAnnotation {
+		name := p.tok.val
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		if !p.punct("(") {
+			return nil, p.unexpected(`"("`)
+		}
+		v, err := p.json()
+		if err != nil {
+			return nil, err
+		}
+		if err = p.expectPunct(")"); err != nil {
+			return nil, err
+		}
+		props[name] = v
+	}
+	return props, nil
+}
+
+func (p *idlParser) parseProtocol() (*idlProtocol, error) {
+	doc := p.tok.doc
+	props, err := p.parseAnnotations()
+	if err != nil {
+		return nil, err
+	}
+	if err = p.expectKeyword("protocol"); err != nil {
+		return nil, err
+	}
+	name, err := p.expectIdent()
+	if err != nil {
+		return nil, err
+	}
+	if err = p.expectPunct("{"); err != nil {
+		return nil, err
+	}
+
+	proto := &idlProtocol{name: name, doc: doc, props: props}
+	if ns, ok := props["namespace"]; ok {
+		s, ok := ns.(string)
+		if !ok {
+			return nil, errors.New("namespace must be a string")
+		}
+		proto.namespace = s
+		delete(props, "namespace")
+	}
+
+	for !p.punct("}") {
+		if p.tok.kind == idlEOF {
+			return nil, p.unexpected(`"}"`)
+		}
+		if err = p.parseDeclaration(proto); err != nil {
+			return nil, err
+		}
+	}
+	if err = p.next(); err != nil {
+		return nil, err
+	}
+	if p.tok.kind != idlEOF {
+		return nil, p.unexpected("end of input")
+	}
+	return proto, nil
+}
+
+func (p *idlParser) parseDeclaration(proto *idlProtocol) error {
+	if p.keyword("import") {
+		return p.parseImport(proto)
+	}
+
+	doc := p.tok.doc
+	props, err := p.parseAnnotations()
+	if err != nil {
+		return err
+	}
+
+	var schema map[string]any
+	switch {
+	case p.keyword("record"), p.keyword("error"):
+		schema, err = p.parseRecord()
+	case p.keyword("enum"):
+		schema, err = p.parseEnum()
+	case p.keyword("fixed"):
+		schema, err = p.parseFixed()
+	default:
+		return p.parseMessage(proto, doc, props)
+	}
+	if err != nil {
+		return err
+	}
+
+	for k, v := range props {
+		schema[k] = v
+	}
+	if doc != "" {
+		schema["doc"] = doc
+	}
+	proto.types = append(proto.types, idlSchema{namespace: proto.namespace, schema: schema})
+	return nil
+}
+
+func (p *idlParser) parseImport(proto *idlProtocol) error {
+	if err := p.next(); err != nil {
+		return err
+	}
+	kind, err := p.expectIdent()
+	if err != nil {
+		return err
+	}
+	if kind != "idl" && kind != "protocol" && kind != "schema" {
+		return fmt.Errorf("unknown import type %q", kind)
+	}
+	if p.tok.kind != idlString {
+		return p.unexpected("import path")
+	}
+	path := p.tok.val
+	if err = p.next(); err != nil {
+		return err
+	}
+	if err = p.expectPunct(";"); err != nil {
+		return err
+	}
+
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(p.dir, path)
+	}
+	if _, ok := p.imported[path]; ok {
+		return nil
+	}
+	p.imported[path] = struct{}{}
+
+	b, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return err
+	}
+
+	switch kind {
+	case "idl":
+		imp, err := parseIDLProtocol(string(b), filepath.Dir(path), p.imported)
+		if err != nil {
+			return err
+		}
+		proto.types = append(proto.types, imp.types...)
+		proto.messages = append(proto.messages, imp.messages...)
+
+	case "protocol":
+		var m map[string]any
+		if err = jsoniter.Unmarshal(b, &m); err != nil {
+			return fmt.Errorf("importing %s: %w", path, err)
+		}
+		ns, _ := m["namespace"].(string)
+		types, _ := m["types"].([]any)
+		for _, typ := range types {
+			proto.types = append(proto.types, idlSchema{namespace: ns, schema: typ})
+		}
+		msgs, _ := m["messages"].(map[string]any)
+		for name, msg := range msgs {
+			msg, ok := msg.(map[string]any)
+			if !ok {
+				return fmt.Errorf("importing %s: invalid message %q", path, name)
+			}
+			proto.messages = append(proto.messages, idlMessage{name: name, namespace: ns, message: msg})
+		}
+
+	case "schema":
+		var v any
+		if err = jsoniter.Unmarshal(b, &v); err != nil {
+			return fmt.Errorf("importing %s: %w", path, err)
+		}
+		if types, ok := v.([]any); ok {
+			for _, typ := range types {
+				proto.types = append(proto.types, idlSchema{schema: typ})
+			}
+			break
+		}
+		proto.types = append(proto.types, idlSchema{schema: v})
+	}
+	return nil
+}
+
+func (p *idlParser) parseRecord() (map[string]any, error) {
+	typ := p.tok.val
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	name, err := p.expectIdent()
+	if err != nil {
+		return nil, err
+	}
+	if err = p.expectPunct("{"); err != nil {
+		return nil, err
+	}
+
+	fields := []any{}
+	for !p.punct("}") {
+		f, err := p.parseFields()
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, f...)
+		if err = p.expectPunct(";"); err != nil {
+			return nil, err
+		}
+	}
+	if err = p.next(); err != nil {
+		return nil, err
+	}
+
+	return map[string]any{"type": typ, "name": name, "fields": fields}, nil
+}
+
+// parseFields parses a type followed by one or more field names.
+func (p *idlParser) parseFields() ([]any, error) {
+	doc := p.tok.doc
+	typ, optional, err := p.parseType()
+	if err != nil {
+		return nil, err
+	}
+
+	var fields []any
+	for {
+		field, err := p.parseVariable(typ, optional, doc)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, field)
+
+		if !p.punct(",") {
+			return fields, nil
+		}
+		if err = p.next(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *idlParser) parseVariable(typ any, optional bool, doc string) (map[string]any, error) {
+	if p.tok.doc != "" {
+		doc = p.tok.doc
+	}
+	field, err := p.parseAnnotations()
+	if err != nil {
+		return nil, err
+	}
+	name, err := p.expectIdent()
+	if err != nil {
+		return nil, err
+	}
+	field["name"] = name
+	if doc != "" {
+		field["doc"] = doc
+	}
+
+	var def any
+	if p.punct("=") {
+		def, err = p.json()
+		if err != nil {
+			return nil, err
+		}
+		field["default"] = def
+	}
+
+	field["type"] = typ
+	if optional {
+		if def != nil {
+			field["type"] = []any{typ, "null"}
+		} else {
+			field["type"] = []any{"null", typ}
+		}
+	}
+	return field, nil
+}
+
+func (p *idlParser) parseEnum() (map[string]any, error) {
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	name, err := p.expectIdent()
+	if err != nil {
+		return nil, err
+	}
+	if err = p.expectPunct("{"); err != nil {
+		return nil, err
+	}
+
+	symbols := []any{}
+	for !p.punct("}") {
+		if len(symbols) > 0 {
+			if err = p.expectPunct(","); err != nil {
+				return nil, err
+			}
+		}
+		sym, err := p.expectIdent()
+		if err != nil {
+			return nil, err
+		}
+		symbols = append(symbols, sym)
+	}
+	if err = p.next(); err != nil {
+		return nil, err
+	}
+
+	schema := map[string]any{"type": "enum", "name": name, "symbols": symbols}
+	if p.punct("=") {
+		if err = p.next(); err != nil {
+			return nil, err
+		}
+		def, err := p.expectIdent()
+		if err != nil {
+			return nil, err
+		}
+		schema["default"] = def
+		if err = p.expectPunct(";"); err != nil {
+			return nil, err
+		}
+	}
+	return schema, nil
+}
+
+func (p *idlParser) parseFixed() (map[string]any, error) {
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	name, err := p.expectIdent()
+	if err != nil {
+		return nil, err
+	}
+	if err = p.expectPunct("("); err != nil {
+		return nil, err
+	}
+	size, err := p.expectInt()
+	if err != nil {
+		return nil, err
+	}
+	if err = p.expectPunct(")"); err != nil {
+		return nil, err
+	}
+	if err = p.expectPunct(";"); err != nil {
+		return nil, err
+	}
+
+	return map[string]any{"type": "fixed", "name": name, "size": size}, nil
+}
+
+func (p *idlParser) expectInt() (int, error) {
+	if p.tok.kind != idlNumber {
+		return 0, p.unexpected("integer")
+	}
+	i, err := strconv.Atoi(p.tok.val)
+	if err != nil {
+		return 0, fmt.Errorf("invalid integer %q", p.tok.val)
+	}
+	return i, p.next()
+}
+
+func (p *idlParser) parseMessage(proto *idlProtocol, doc string, props map[string]any) error {
+	var (
+		resp any
+		err  error
+	)
+	if p.keyword("void") {
+		resp = "null"
+		err = p.next()
+	} else {
+		var optional bool
+		resp, optional, err = p.parseType()
+		if optional {
+			resp = []any{"null", resp}
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	name, err := p.expectIdent()
+	if err != nil {
+		return err
+	}
+	if err = p.expectPunct("("); err != nil {
+		return err
+	}
+	request := []any{}
+	for !p.punct(")") {
+		if len(request) > 0 {
+			if err = p.expectPunct(","); err != nil {
+				return err
+			}
+		}
+		doc := p.tok.doc
+		typ, optional, err := p.parseType()
+		if err != nil {
+			return err
+		}
+		param, err := p.parseVariable(typ, optional, doc)
+		if err != nil {
+			return err
+		}
+		request = append(request, param)
+	}
+	if err = p.next(); err != nil {
+		return err
+	}
+
+	msg := props
+	msg["request"] = request
+	msg["response"] = resp
+	if doc != "" {
+		msg["doc"] = doc
+	}
+
+	switch {
+	case p.keyword("oneway"):
+		msg["one-way"] = true
+		if err = p.next(); err != nil {
+			return err
+		}
+
+	case p.keyword("throws"):
+		var errs []any
+		for {
+			if err = p.next(); err != nil {
+				return err
+			}
+			name, err := p.expectIdent()
+			if err != nil {
+				return err
+			}
+			errs = append(errs, name)
+			if !p.punct(",") {
+				break
+			}
+		}
+		msg["errors"] = errs
+	}
+	if err = p.expectPunct(";"); err != nil {
+		return err
+	}
+
+	proto.messages = append(proto.messages, idlMessage{name: name, namespace: proto.namespace, message: msg})
+	return nil
+}
+
+// idlLogicalTypes are the IDL keywords for logical types.
+var idlLogicalTypes = map[string]map[string]any{
+	"date":               {"type": "int", "logicalType": "date"},
+	"time_ms":            {"type": "int", "logicalType": "time-millis"},
+	"timestamp_ms":       {"type": "long", "logicalType": "timestamp-millis"},
+	"local_timestamp_ms": {"type": "long", "logicalType": "local-timestamp-millis"},
+	"uuid":               {"type": "string", "logicalType": "uuid"},
+}
+
+// parseType parses a type, returning its JSON form and whether
+// it is marked optional with "?".
+func (p *idlParser) parseType() (any, bool, error) {
+	props, err := p.parseAnnotations()
+	if err != nil {
+		return nil, false, err
+	}
+	if p.tok.kind != idlIdent {
+		return nil, false, p.unexpected("type")
+	}
+
+	var schema map[string]any
+	kw := p.tok.val
+	if p.tok.quoted {
+		kw = ""
+	}
+	switch kw {
+	case "null", "boolean", "int", "long", "float", "double", "bytes", "string":
+		schema = map[string]any{"type": kw}
+		err = p.next()
+
+	case "date", "time_ms", "timestamp_ms", "local_timestamp_ms", "uuid":
+		schema = map[string]any{}
+		for k, v := range idlLogicalTypes[kw] {
+			schema[k] = v
+		}
+		err = p.next()
+
+	case "decimal":
+		schema, err = p.parseDecimal()
+
+	case "array", "map":
+		schema, err = p.parseCollection(kw)
+
+	case "union":
+		if len(props) > 0 {
+			return nil, false, errors.New("annotations are not supported on unions")
+		}
+		var union []any
+		union, err = p.parseUnion()
+		if err != nil {
+			return nil, false, err
+		}
+		return union, false, nil
+
+	default:
+		if len(props) > 0 {
+			return nil, false, fmt.Errorf("annotations are not supported on type reference %q", p.tok.val)
+		}
+		ref := p.tok.val
+		if err = p.next(); err != nil {
+			return nil, false, err
+		}
+		optional, err := p.optional()
+		return ref, optional, err
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	for k, v := range props {
+		schema[k] = v
+	}
+	var typ any = schema
+	if len(schema) == 1 {
+		typ = schema["type"]
+	}
+	optional, err := p.optional()
+	return typ, optional, err
+}
+
+// optional consumes the "?" marking an optional type.
+func (p *idlParser) optional() (bool, error) {
+	if !p.punct("?") {
+		return false, nil
+	}
+	return true, p.next()
+}
+
+func (p *idlParser) parseDecimal() (map[string]any, error) {
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	if err := p.expectPunct("("); err != nil {
+		return nil, err
+	}
+	prec, err := p.expectInt()
+	if err != nil {
+		return nil, err
+	}
+	scale := 0
+	if p.punct(",") {
+		if err = p.next(); err != nil {
+			return nil, err
+		}
+		if scale, err = p.expectInt(); err != nil {
+			return nil, err
+		}
+	}
+	if err = p.expectPunct(")"); err != nil {
+		return nil, err
+	}
+
+	return map[string]any{"type": "bytes", "logicalType": "decimal", "precision": prec, "scale": scale}, nil
+}
+
+func (p *idlParser) parseCollection(kw string) (map[string]any, error) {
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	if err := p.expectPunct("<"); err != nil {
+		return nil, err
+	}
+	typ, optional, err := p.parseType()
+	if err != nil {
+		return nil, err
+	}
+	if optional {
+		typ = []any{"null", typ}
+	}
+	if err = p.expectPunct(">"); err != nil {
+		return nil, err
+	}
+
+	if kw == "array" {
+		return map[string]any{"type": "array", "items": typ}, nil
+	}
+	return map[string]any{"type": "map", "values": typ}, nil
+}
+
+func (p *idlParser) parseUnion() ([]any, error) {
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	if err := p.expectPunct("{"); err != nil {
+		return nil, err
+	}
+
+	var types []any
+	for !p.punct("}") {
+		if len(types) > 0 {
+			if err := p.expectPunct(","); err != nil {
+				return nil, err
+			}
+		}
+		typ, optional, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+		if optional {
+			return nil, errors.New("optional types are not supported in unions")
+		}
+		types = append(types, typ)
+	}
+	return types, p.next()
+}
+
+type idlTokenKind int
+
+const (
+	idlEOF idlTokenKind = iota
+	idlIdent
+	idlString
+	idlNumber
+	idlAnnotation
+	idlPunct
+)
+
+type idlToken struct {
+	kind   idlTokenKind
+	val    string
+	quoted bool
+	line   int
+
+	// doc is the doc comment preceding the token.
+	doc string
+}
+
+type idlLexer struct {
+	src  string
+	pos  int
+	line int
+}
+
+func (l *idlLexer) next() (idlToken, error) {
+	doc, err := l.skip()
+	if err != nil {
+		return idlToken{}, err
+	}
+
+	tok := idlToken{line: l.line, doc: doc}
+	if l.pos >= len(l.src) {
+		tok.kind = idlEOF
+		return tok, nil
+	}
+
+	c := l.src[l.pos]
+	switch {
+	case isIDLIdentStart(c):
+		start := l.pos
+		for l.pos < len(l.src) && isIDLIdent(l.src[l.pos]) {
+			l.pos++
+		}
+		tok.kind = idlIdent
+		tok.val = l.src[start:l.pos]
+
+	case c == '`':
+		end := strings.IndexByte(l.src[l.pos+1:], '`')
+		if end < 0 {
+			return idlToken{}, errors.New("unterminated quoted identifier")
+		}
+		tok.kind = idlIdent
+		tok.val = l.src[l.pos+1 : l.pos+1+end]
+		tok.quoted = true
+		l.pos += end + 2
+
+	case c == '@':
+		start := l.pos + 1
+		l.pos++
+		for l.pos < len(l.src) && (isIDLIdent(l.src[l.pos]) || l.src[l.pos] == '-') {
+			l.pos++
+		}
+		if l.pos == start {
+			return idlToken{}, errors.New("annotation requires a name")
+		}
+		tok.kind = idlAnnotation
+		tok.val = l.src[start:l.pos]
+
+	case c == '"':
+		v, err := l.json()
+		if err != nil {
+			return idlToken{}, err
+		}
+		tok.kind = idlString
+		tok.val = v.(string)
+
+	case c == '-' || (c >= '0' && c <= '9'):
+		start := l.pos
+		l.pos++
+		for l.pos < len(l.src) && l.src[l.pos] >= '0' && l.src[l.pos] <= '9' {
+			l.pos++
+		}
+		tok.kind = idlNumber
+		tok.val = l.src[start:l.pos]
+
+	case strings.IndexByte("{}()<>[],;=?", c) >= 0:
+		tok.kind = idlPunct
+		tok.val = string(c)
+		l.pos++
+
+	default:
+		return idlToken{}, fmt.Errorf("unexpected character %q", c)
+	}
+	return tok, nil
+}
+
+// skip skips whitespace and comments, returning the last doc comment.
+func (l *idlLexer) skip() (string, error) {
+	var doc string
+	for l.pos < len(l.src) {
+		switch c := l.src[l.pos]; {
+		case c == '\n':
+			l.line++
+			l.pos++
+
+		case c == ' ' || c == '\t' || c == '\r':
+			l.pos++
+
+		case strings.HasPrefix(l.src[l.pos:], "//"):
+			end := strings.IndexByte(l.src[l.pos:], '\n')
+			if end < 0 {
+				end = len(l.src) - l.pos
+			}
+			l.pos += end
+
+		case strings.HasPrefix(l.src[l.pos:], "/*"):
+			end := strings.Index(l.src[l.pos+2:], "*/")
+			if end < 0 {
+				return "", errors.New("unterminated comment")
+			}
+			comment := l.src[l.pos : l.pos+end+4]
+			l.line += strings.Count(comment, "\n")
+			l.pos += len(comment)
+
+			if strings.HasPrefix(comment, "/**") && comment != "/**/" {
+				doc = idlDoc(comment[3 : len(comment)-2])
+			}
+
+		default:
+			return doc, nil
+		}
+	}
+	return doc, nil
+}
+
+// json reads a JSON value at the current position.
+func (l *idlLexer) json() (any, error) {
+	if _, err := l.skip(); err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(strings.NewReader(l.src[l.pos:]))
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("invalid json value: %w", err)
+	}
+
+	n := int(dec.InputOffset())
+	l.line += strings.Count(l.src[l.pos:l.pos+n], "\n")
+	l.pos += n
+	return v, nil
+}
+
+// idlDoc trims the comment markers from a doc comment.
+func idlDoc(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		if i > 0 {
+			line = strings.TrimPrefix(line, "*")
+			line = strings.TrimPrefix(line, " ")
+		}
+		lines[i] = line
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+func isIDLIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIDLIdent(c byte) bool {
+	return isIDLIdentStart(c) || c == '.' || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,242 @@
+package avro_test
+
+import (
+	"testing"
+
+	"github.com/kjuulh/avro/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIDLFile(t *testing.T) {
+	protocol, err := avro.ParseIDLFile("testdata/echo.avdl")
+	require.NoError(t, err)
+
+	want, err := avro.ParseProtocolFile("testdata/echo.avpr")
+	require.NoError(t, err)
+	assert.Equal(t, want.String(), protocol.String())
+	assert.Equal(t, want.Hash(), protocol.Hash())
+	assert.Equal(t, "Simple echo protocol", protocol.Doc())
+}
+
+func TestParseIDLFile_InvalidPath(t *testing.T) {
+	_, err := avro.ParseIDLFile("test.avdl")
+
+	assert.Error(t, err)
+}
+
+func TestParseIDLFile_Imports(t *testing.T) {
+	protocol, err := avro.ParseIDLFile("testdata/idl/imports.avdl")
+	require.NoError(t, err)
+
+	var names []string
+	for _, typ := range protocol.Types() {
+		names = append(names, typ.FullName())
+	}
+	wantNames := []string{
+		"org.hamba.avro.common.Color",
+		"org.hamba.avro.Ping",
+		"org.hamba.avro.Pong",
+		"org.hamba.avro.PongError",
+		"org.hamba.avro.shapes.Point",
+		"org.hamba.avro.imports.Shape",
+	}
+	assert.Equal(t, wantNames, names)
+	assert.NotNil(t, protocol.Message("reset"))
+	assert.NotNil(t, protocol.Message("ping"))
+}
+
+func TestParseIDL_Record(t *testing.T) {
+	idl := `
+// A protocol.
+@namespace("org.hamba.avro")
+protocol Test {
+	/** An enum. */
+	@aliases(["org.hamba.avro.OldKind"])
+	enum Kind {
+		FOO, BAR, BAZ
+	} = FOO;
+
+	fixed MD5(16);
+
+	/**
+	 * A record.
+	 */
+	@namespace("org.hamba.avro.other")
+	@foo("bar")
+	record TestRecord {
+		/** The name. */
+		string @order("ignore") name;
+		org.hamba.avro.Kind @order("descending") kind = "BAR";
+		org.hamba.avro.MD5? hash;
+		union { null, string } /** A nickname. */ @aliases(["nick"]) nickname = null;
+		string? title = "none";
+		array<long> longs = [1, 2];
+		map<int?> ints;
+		@logicalType("timestamp-micros") long ts;
+		date day;
+		decimal(9, 2) amount;
+		uuid id;
+		int a, b = 1;
+		boolean ` + "`error`" + `;
+	}
+}`
+
+	protocol, err := avro.ParseIDL(idl)
+	require.NoError(t, err)
+
+	require.Len(t, protocol.Types(), 3)
+	enum := protocol.Types()[0].(*avro.EnumSchema)
+	assert.Equal(t, "An enum.", enum.Doc())
+	assert.Equal(t, []string{"org.hamba.avro.OldKind"}, enum.Aliases())
+	assert.Equal(t, "FOO", enum.Default())
+
+	rec := protocol.Types()[2].(*avro.RecordSchema)
+	assert.Equal(t, "org.hamba.avro.other.TestRecord", rec.FullName())
+	assert.Equal(t, "A record.", rec.Doc())
+	assert.Equal(t, "bar", rec.Prop("foo"))
+
+	want := `{"name":"org.hamba.avro.other.TestRecord","type":"record","fields":[` +
+		`{"name":"name","type":"string"},` +
+		`{"name":"kind","type":"org.hamba.avro.Kind"},` +
+		`{"name":"hash","type":["null","org.hamba.avro.MD5"]},` +
+		`{"name":"nickname","type":["null","string"]},` +
+		`{"name":"title","type":["string","null"]},` +
+		`{"name":"longs","type":{"type":"array","items":"long"}},` +
+		`{"name":"ints","type":{"type":"map","values":["null","int"]}},` +
+		`{"name":"ts","type":{"type":"long","logicalType":"timestamp-micros"}},` +
+		`{"name":"day","type":{"type":"int","logicalType":"date"}},` +
+		`{"name":"amount","type":{"type":"bytes","logicalType":"decimal","precision":9,"scale":2}},` +
+		`{"name":"id","type":{"type":"string","logicalType":"uuid"}},` +
+		`{"name":"a","type":"int"},` +
+		`{"name":"b","type":"int"},` +
+		`{"name":"error","type":"boolean"}]}`
+	assert.Equal(t, want, rec.String())
+
+	fields := rec.Fields()
+	assert.Equal(t, "The name.", fields[0].Doc())
+	assert.Equal(t, avro.Ignore, fields[0].Order())
+	assert.Equal(t, avro.Desc, fields[1].Order())
+	assert.Equal(t, "BAR", fields[1].Default())
+	assert.False(t, fields[2].HasDefault())
+	assert.Equal(t, "A nickname.", fields[3].Doc())
+	assert.Equal(t, []string{"nick"}, fields[3].Aliases())
+	assert.True(t, fields[3].HasDefault())
+	assert.Nil(t, fields[3].Default())
+	assert.Equal(t, "none", fields[4].Default())
+	assert.Equal(t, []any{int64(1), int64(2)}, fields[5].Default())
+	assert.False(t, fields[11].HasDefault())
+	assert.Equal(t, 1, fields[12].Default())
+}
+
+func TestParseIDL_Messages(t *testing.T) {
+	idl := `
+@namespace("org.hamba.avro")
+protocol Test {
+	error TestError {
+		string message;
+	}
+
+	/** Says hello. */
+	string hello(string greeting, int count = 1);
+	void ping() oneway;
+	void fail(string ` + "`reason`" + `) throws TestError;
+	@deprecated(true) bytes? echo(bytes data);
+}`
+
+	protocol, err := avro.ParseIDL(idl)
+	require.NoError(t, err)
+
+	hello := protocol.Message("hello")
+	require.NotNil(t, hello)
+	assert.Equal(t, "Says hello.", hello.Doc())
+	assert.Equal(t, `{"request":[{"name":"greeting","type":"string"},{"name":"count","type":"int"}],"response":"string"}`, hello.String())
+	assert.False(t, hello.OneWay())
+
+	ping := protocol.Message("ping")
+	require.NotNil(t, ping)
+	assert.True(t, ping.OneWay())
+
+	fail := protocol.Message("fail")
+	require.NotNil(t, fail)
+	assert.False(t, fail.OneWay())
+	assert.Equal(t, `{"request":[{"name":"reason","type":"string"}],"errors":["org.hamba.avro.TestError"]}`, fail.String())
+
+	echo := protocol.Message("echo")
+	require.NotNil(t, echo)
+	assert.Equal(t, true, echo.Prop("deprecated"))
+	assert.Equal(t, `["null","bytes"]`, echo.Response().String())
+}
+
+func TestParseIDL_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		idl  string
+	}{
+		{
+			name: "missing protocol",
+			idl:  `record Foo {}`,
+		},
+		{
+			name: "unterminated protocol",
+			idl:  `protocol Foo { record Bar { string a; }`,
+		},
+		{
+			name: "trailing input",
+			idl:  `protocol Foo {} foo`,
+		},
+		{
+			name: "unterminated comment",
+			idl:  `protocol Foo { /* foo }`,
+		},
+		{
+			name: "missing semicolon",
+			idl:  `protocol Foo { record Bar { string a } }`,
+		},
+		{
+			name: "invalid default",
+			idl:  `protocol Foo { record Bar { int a = "foo"; } }`,
+		},
+		{
+			name: "invalid json",
+			idl:  `protocol Foo { record Bar { int a = {; } }`,
+		},
+		{
+			name: "unknown type",
+			idl:  `protocol Foo { record Bar { Baz a; } }`,
+		},
+		{
+			name: "annotated reference",
+			idl:  `protocol Foo { fixed Baz(2); record Bar { @foo("bar") Baz a; } }`,
+		},
+		{
+			name: "invalid fixed size",
+			idl:  `protocol Foo { fixed Baz(a); }`,
+		},
+		{
+			name: "unknown import",
+			idl:  `protocol Foo { import foo "bar.avdl"; }`,
+		},
+		{
+			name: "missing import",
+			idl:  `protocol Foo { import idl "testdata/missing.avdl"; }`,
+		},
+		{
+			name: "oneway with response",
+			idl:  `protocol Foo { string bar() oneway; }`,
+		},
+		{
+			name: "unexpected character",
+			idl:  `protocol Foo { record Bar { string a: } }`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			_, err := avro.ParseIDL(test.idl)
+
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,502 @@
+package avro
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"unicode/utf8"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// jsonAPI is the json configuration used to read Avro JSON encoded data.
+// Numbers are kept as json.Number to avoid losing long precision.
+var jsonAPI = jsoniter.Config{UseNumber: true}.Froze()
+
+// JSONEncoder writes Avro JSON encoded values to an output stream.
+type JSONEncoder struct {
+	cfg    *frozenConfig
+	s      Schema
+	stream *jsoniter.Stream
+}
+
+// NewJSONEncoder returns a new JSON encoder that writes to w using schema s.
+func NewJSONEncoder(s string, w io.Writer) (*JSONEncoder, error) {
+	sch, err := Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	return NewJSONEncoderForSchema(sch, w), nil
+}
+
+// NewJSONEncoderForSchema returns a new JSON encoder that writes to w using schema.
+func NewJSONEncoderForSchema(schema Schema, w io.Writer) *JSONEncoder {
+	return DefaultConfig.NewJSONEncoder(schema, w)
+}
+
+// Encode writes the Avro JSON encoding of v to the stream, followed by a newline.
+func (e *JSONEncoder) Encode(v any) error {
+	if err := e.cfg.writeJSON(e.stream, e.s, v); err != nil {
+		return err
+	}
+	e.stream.WriteRaw("\n")
+	return e.stream.Flush()
+}
+
+// JSONDecoder reads and decodes Avro JSON encoded values from an input stream.
+type JSONDecoder struct {
+	cfg  *frozenConfig
+	s    Schema
+	iter *jsoniter.Iterator
+}
+
+// NewJSONDecoder returns a new JSON decoder that reads from reader r using schema s.
+func NewJSONDecoder(s string, r io.Reader) (*JSONDecoder, error) {
+	sch, err := Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	return NewJSONDecoderForSchema(sch, r), nil
+}
+
+// NewJSONDecoderForSchema returns a new JSON decoder that reads from r using schema.
+func NewJSONDecoderForSchema(schema Schema, r io.Reader) *JSONDecoder {
+	return DefaultConfig.NewJSONDecoder(schema, r)
+}
+
+// Decode reads the next Avro JSON encoded value from its input and stores it in the value pointed to by v.
+func (d *JSONDecoder) Decode(v any) error {
+	if d.iter.WhatIsNext() == jsoniter.InvalidValue {
+		if d.iter.Error == nil || errors.Is(d.iter.Error, io.EOF) {
+			return io.EOF
+		}
+		return d.iter.Error
+	}
+	val := d.iter.Read()
+	if d.iter.Error != nil && !errors.Is(d.iter.Error, io.EOF) {
+		return d.iter.Error
+	}
+
+	return d.cfg.unmarshalJSONValue(d.s, val, v)
+}
+
+// MarshalJSONEncoding returns the Avro JSON encoding of v.
+func MarshalJSONEncoding(schema Schema, v any) ([]byte, error) {
+	return DefaultConfig.MarshalJSONEncoding(schema, v)
+}
+
+// UnmarshalJSONEncoding parses the Avro JSON encoded data and stores the result in the value pointed to by v.
+// If v is nil or not a pointer, UnmarshalJSONEncoding returns an error.
+func UnmarshalJSONEncoding(schema Schema, data []byte, v any) error {
+	return DefaultConfig.UnmarshalJSONEncoding(schema, data, v)
+}
+
+func (c *frozenConfig) MarshalJSONEncoding(schema Schema, v any) ([]byte, error) {
+	stream := jsonAPI.BorrowStream(nil)
+	defer jsonAPI.ReturnStream(stream)
+
+	if err := c.writeJSON(stream, schema, v); err != nil {
+		return nil, err
+	}
+
+	result := stream.Buffer()
+	copied := make([]byte, len(result))
+	copy(copied, result)
+	return copied, nil
+}
+
+func (c *frozenConfig) UnmarshalJSONEncoding(schema Schema, data []byte, v any) error {
+	var val any
+	if err := jsonAPI.Unmarshal(data, &val); err != nil {
+		return fmt.Errorf("avro: %w", err)
+	}
+
+	return c.unmarshalJSONValue(schema, val, v)
+}
+
+func (c *frozenConfig) NewJSONEncoder(schema Schema, w io.Writer) *JSONEncoder {
+	return &JSONEncoder{
+		cfg:    c,
+		s:      schema,
+		stream: jsoniter.NewStream(jsonAPI, w, 512),
+	}
+}
+
+func (c *frozenConfig) NewJSONDecoder(schema Schema, r io.Reader) *JSONDecoder {
+	return &JSONDecoder{
+		cfg:  c,
+		s:    schema,
+		iter: jsoniter.Parse(jsonAPI, r, 512),
+	}
+}
+
+// writeJSON encodes v using the binary codecs, then transcodes the
+// result to the Avro JSON encoding. This keeps the struct rules of both
+// encodings identical.
+func (c *frozenConfig) writeJSON(stream *jsoniter.Stream, schema Schema, v any) error {
+	writer := c.borrowWriter()
+	defer c.returnWriter(writer)
+
+	writer.WriteVal(schema, v)
+	if writer.Error != nil {
+		return writer.Error
+	}
+
+	reader := c.borrowReader(writer.Buffer())
+	defer c.returnReader(reader)
+
+	writeBinaryAsJSON(reader, schema, stream)
+	if reader.Error != nil && !errors.Is(reader.Error, io.EOF) {
+		return reader.Error
+	}
+	return stream.Error
+}
+
+// unmarshalJSONValue transcodes a generic JSON value to the binary
+// encoding, then decodes it using the binary codecs.
+func (c *frozenConfig) unmarshalJSONValue(schema Schema, val, v any) error {
+	writer := c.borrowWriter()
+	defer c.returnWriter(writer)
+
+	if err := c.writeJSONAsBinary(writer, schema, val); err != nil {
+		return err
+	}
+
+	// The pooled writer buffer is reused, decoded values must not reference it.
+	return c.unmarshal(c.borrowReader(writer.Buffer()), schema, v)
+}
+
+func writeBinaryAsJSON(r *Reader, schema Schema, stream *jsoniter.Stream) {
+	if r.Error != nil {
+		return
+	}
+
+	switch schema.Type() {
+	case Null:
+		stream.WriteNil()
+	case Boolean:
+		stream.WriteBool(r.ReadBool())
+	case Int:
+		stream.WriteInt32(r.ReadInt())
+	case Long:
+		stream.WriteInt64(r.ReadLong())
+	case Float:
+		writeJSONFloat(stream, float64(r.ReadFloat()), 32)
+	case Double:
+		writeJSONFloat(stream, r.ReadDouble(), 64)
+	case String:
+		stream.WriteString(r.ReadString())
+	case Bytes:
+		stream.WriteString(bytesToJSONString(r.ReadBytes()))
+	case Fixed:
+		b := make([]byte, schema.(*FixedSchema).Size())
+		r.Read(b)
+		stream.WriteString(bytesToJSONString(b))
+	case Enum:
+		enum := schema.(*EnumSchema)
+		symbol, ok := enum.Symbol(int(r.ReadInt()))
+		if !ok {
+			r.ReportError("decode enum symbol", "unknown enum symbol")
+			return
+		}
+		stream.WriteString(symbol)
+	case Record:
+		stream.WriteObjectStart()
+		for i, field := range schema.(*RecordSchema).Fields() {
+			if i > 0 {
+				stream.WriteMore()
+			}
+			stream.WriteObjectField(field.Name())
+			writeBinaryAsJSON(r, field.Type(), stream)
+		}
+		stream.WriteObjectEnd()
+	case Ref:
+		writeBinaryAsJSON(r, schema.(*RefSchema).Schema(), stream)
+	case Array:
+		items := schema.(*ArraySchema).Items()
+		first := true
+		stream.WriteArrayStart()
+		r.ReadArrayCB(func(r *Reader) bool {
+			if !first {
+				stream.WriteMore()
+			}
+			first = false
+			writeBinaryAsJSON(r, items, stream)
+			return r.Error == nil
+		})
+		stream.WriteArrayEnd()
+	case Map:
+		values := schema.(*MapSchema).Values()
+		first := true
+		stream.WriteObjectStart()
+		r.ReadMapCB(func(r *Reader, key string) bool {
+			if !first {
+				stream.WriteMore()
+			}
+			first = false
+			stream.WriteObjectField(key)
+			writeBinaryAsJSON(r, values, stream)
+			return r.Error == nil
+		})
+		stream.WriteObjectEnd()
+	case Union:
+		_, typ := getUnionSchema(schema.(*UnionSchema), r)
+		if typ == nil {
+			return
+		}
+		if typ.Type() == Null {
+			stream.WriteNil()
+			return
+		}
+		stream.WriteObjectStart()
+		stream.WriteObjectField(jsonUnionName(typ))
+		writeBinaryAsJSON(r, typ, stream)
+		stream.WriteObjectEnd()
+	default:
+		r.ReportError("WriteJSON", fmt.Sprintf("unexpected schema type: %v", schema.Type()))
+	}
+}
+
+func writeJSONFloat(stream *jsoniter.Stream, f float64, bitSize int) {
+	switch {
+	case math.IsNaN(f):
+		stream.WriteString("NaN")
+	case math.IsInf(f, 1):
+		stream.WriteString("Infinity")
+	case math.IsInf(f, -1):
+		stream.WriteString("-Infinity")
+	default:
+		stream.WriteRaw(strconv.FormatFloat(f, 'g', -1, bitSize))
+	}
+}
+
+//nolint:maintidx // Splitting this would not make it simpler.
+func (c *frozenConfig) writeJSONAsBinary(w *Writer, schema Schema, val any) error {
+	switch schema.Type() {
+	case Null:
+		if val != nil {
+			return fmt.Errorf("avro: expected null, got %T", val)
+		}
+	case Boolean:
+		b, ok := val.(bool)
+		if !ok {
+			return fmt.Errorf("avro: expected boolean, got %T", val)
+		}
+		w.WriteBool(b)
+	case Int:
+		i, err := jsonInt(val, 32)
+		if err != nil {
+			return err
+		}
+		w.WriteInt(int32(i))
+	case Long:
+		i, err := jsonInt(val, 64)
+		if err != nil {
+			return err
+		}
+		w.WriteLong(i)
+	case Float:
+		f, err := jsonFloat(val, 32)
+		if err != nil {
+			return err
+		}
+		w.WriteFloat(float32(f))
+	case Double:
+		f, err := jsonFloat(val, 64)
+		if err != nil {
+			return err
+		}
+		w.WriteDouble(f)
+	case String:
+		s, ok := val.(string)
+		if !ok {
+			return fmt.Errorf("avro: expected string, got %T", val)
+		}
+		w.WriteString(s)
+	case Bytes:
+		b, err := jsonBytes(val)
+		if err != nil {
+			return err
+		}
+		w.WriteBytes(b)
+	case Fixed:
+		b, err := jsonBytes(val)
+		if err != nil {
+			return err
+		}
+		if size := schema.(*FixedSchema).Size(); len(b) != size {
+			return fmt.Errorf("avro: expected fixed of size %d, got %d", size, len(b))
+		}
+		_, _ = w.Write(b)
+	case Enum:
+		s, ok := val.(string)
+		if !ok {
+			return fmt.Errorf("avro: expected enum symbol, got %T", val)
+		}
+		idx := -1
+		for i, sym := range schema.(*EnumSchema).Symbols() {
+			if sym == s {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			return fmt.Errorf("avro: unknown enum symbol: %s", s)
+		}
+		w.WriteInt(int32(idx))
+	case Record:
+		rec := schema.(*RecordSchema)
+		obj, ok := val.(map[string]any)
+		if !ok {
+			return fmt.Errorf("avro: expected object for record %s, got %T", rec.FullName(), val)
+		}
+		for _, field := range rec.Fields() {
+			fieldVal, ok := obj[field.Name()]
+			if !ok {
+				if !field.HasDefault() {
+					return fmt.Errorf("avro: record %s is missing required field %q", rec.FullName(), field.Name())
+				}
+				b, err := encodeFieldDefault(c, field)
+				if err != nil {
+					return fmt.Errorf("%s: %w", field.Name(), err)
+				}
+				_, _ = w.Write(b)
+				continue
+			}
+			if err := c.writeJSONAsBinary(w, field.Type(), fieldVal); err != nil {
+				return fmt.Errorf("%s: %w", field.Name(), err)
+			}
+		}
+	case Ref:
+		return c.writeJSONAsBinary(w, schema.(*RefSchema).Schema(), val)
+	case Array:
+		arr, ok := val.([]any)
+		if !ok {
+			return fmt.Errorf("avro: expected array, got %T", val)
+		}
+		items := schema.(*ArraySchema).Items()
+		if len(arr) > 0 {
+			w.WriteLong(int64(len(arr)))
+			for _, item := range arr {
+				if err := c.writeJSONAsBinary(w, items, item); err != nil {
+					return err
+				}
+			}
+		}
+		w.WriteLong(0)
+	case Map:
+		obj, ok := val.(map[string]any)
+		if !ok {
+			return fmt.Errorf("avro: expected object for map, got %T", val)
+		}
+		values := schema.(*MapSchema).Values()
+		if len(obj) > 0 {
+			w.WriteLong(int64(len(obj)))
+			for k, v := range obj {
+				w.WriteString(k)
+				if err := c.writeJSONAsBinary(w, values, v); err != nil {
+					return fmt.Errorf("%s: %w", k, err)
+				}
+			}
+		}
+		w.WriteLong(0)
+	case Union:
+		types := schema.(*UnionSchema).Types()
+		if val == nil {
+			for i, typ := range types {
+				if typ.Type() == Null {
+					w.WriteLong(int64(i))
+					return nil
+				}
+			}
+			return errors.New("avro: null is not a member of the union")
+		}
+		obj, ok := val.(map[string]any)
+		if !ok || len(obj) != 1 {
+			return fmt.Errorf("avro: expected union object with a single key, got %T", val)
+		}
+		for name, v := range obj {
+			for i, typ := range types {
+				if jsonUnionName(typ) != name {
+					continue
+				}
+				w.WriteLong(int64(i))
+				return c.writeJSONAsBinary(w, typ, v)
+			}
+			return fmt.Errorf("avro: unknown union type %s", name)
+		}
+	default:
+		return fmt.Errorf("avro: schema type %s is unsupported", schema.Type())
+	}
+	return nil
+}
+
+// jsonUnionName returns the name used as the union branch key in JSON.
+// Unlike schemaTypeName, logical types are not part of the name.
+func jsonUnionName(schema Schema) string {
+	if schema.Type() == Ref {
+		schema = schema.(*RefSchema).Schema()
+	}
+	if n, ok := schema.(NamedSchema); ok {
+		return n.FullName()
+	}
+	return string(schema.Type())
+}
+
+func jsonInt(val any, bitSize int) (int64, error) {
+	n, ok := val.(json.Number)
+	if !ok {
+		return 0, fmt.Errorf("avro: expected number, got %T", val)
+	}
+	i, err := strconv.ParseInt(string(n), 10, bitSize)
+	if err != nil {
+		return 0, fmt.Errorf("avro: invalid integer %s: %w", n, err)
+	}
+	return i, nil
+}
+
+func jsonFloat(val any, bitSize int) (float64, error) {
+	switch v := val.(type) {
+	case json.Number:
+		f, err := strconv.ParseFloat(string(v), bitSize)
+		if err != nil {
+			return 0, fmt.Errorf("avro: invalid number %s: %w", v, err)
+		}
+		return f, nil
+	case string:
+		switch v {
+		case "NaN":
+			return math.NaN(), nil
+		case "Infinity":
+			return math.Inf(1), nil
+		case "-Infinity":
+			return math.Inf(-1), nil
+		}
+	}
+	return 0, fmt.Errorf("avro: expected number, got %T", val)
+}
+
+// bytesToJSONString maps each byte to the unicode code point of the same
+// value, as required for bytes and fixed in the JSON encoding.
+func bytesToJSONString(b []byte) string {
+	buf := make([]byte, 0, len(b))
+	for _, c := range b {
+		buf = utf8.AppendRune(buf, rune(c))
+	}
+	return string(buf)
+}
+
+func jsonBytes(val any) ([]byte, error) {
+	s, ok := val.(string)
+	if !ok {
+		return nil, fmt.Errorf("avro: expected string, got %T", val)
+	}
+	b, ok := isValidDefaultBytes(s)
+	if !ok {
+		return nil, errors.New("avro: invalid bytes, code points must be in range 0-255")
+	}
+	return b, nil
+}
@@ -0,0 +1,272 @@
+package avro_test
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"testing"
+
+	"github.com/kjuulh/avro/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type TestJSONRecord struct {
+	A    int64             `avro:"a"`
+	B    string            `avro:"b"`
+	C    []byte            `avro:"c"`
+	D    [4]byte           `avro:"d"`
+	E    string            `avro:"e"`
+	F    *string           `avro:"f"`
+	G    []int             `avro:"g"`
+	H    map[string]bool   `avro:"h"`
+	I    any               `avro:"i"`
+	J    float64           `avro:"j"`
+	Recs []TestJSONSubItem `avro:"recs"`
+}
+
+type TestJSONSubItem struct {
+	Name string `avro:"name"`
+}
+
+var testJSONSchema = `{
+	"type": "record",
+	"name": "test",
+	"namespace": "org.hamba.avro",
+	"fields" : [
+		{"name": "a", "type": "long"},
+		{"name": "b", "type": "string"},
+		{"name": "c", "type": "bytes"},
+		{"name": "d", "type": {"type": "fixed", "name": "fix", "size": 4}},
+		{"name": "e", "type": {"type": "enum", "name": "en", "symbols": ["foo", "bar"]}},
+		{"name": "f", "type": ["null", "string"]},
+		{"name": "g", "type": {"type": "array", "items": "int"}},
+		{"name": "h", "type": {"type": "map", "values": "boolean"}},
+		{"name": "i", "type": ["null", "int", {"type": "long", "logicalType": "timestamp-millis"}]},
+		{"name": "j", "type": "double"},
+		{"name": "recs", "type": {"type": "array", "items": {
+			"type": "record", "name": "sub", "fields": [{"name": "name", "type": "string"}]
+		}}}
+	]
+}`
+
+func TestMarshalJSONEncoding(t *testing.T) {
+	defer ConfigTeardown()
+
+	schema := avro.MustParse(testJSONSchema)
+	str := "baz"
+	in := TestJSONRecord{
+		A:    27,
+		B:    "foo",
+		C:    []byte{0x00, 0x7f, 0xff},
+		D:    [4]byte{'a', 'b', 0xe9, 0x01},
+		E:    "bar",
+		F:    &str,
+		G:    []int{1, 2},
+		H:    map[string]bool{"x": true},
+		I:    12,
+		J:    1.5,
+		Recs: []TestJSONSubItem{{Name: "one"}},
+	}
+
+	got, err := avro.MarshalJSONEncoding(schema, in)
+
+	require.NoError(t, err)
+	want := `{"a":27,"b":"foo","c":"\u0000\u007fÿ","d":"abé\u0001","e":"bar","f":{"string":"baz"},` +
+		`"g":[1,2],"h":{"x":true},"i":{"int":12},"j":1.5,"recs":[{"name":"one"}]}`
+	assert.JSONEq(t, want, string(got))
+}
+
+func TestMarshalJSONEncoding_NullUnion(t *testing.T) {
+	defer ConfigTeardown()
+
+	schema := avro.MustParse(`["null", "string"]`)
+
+	got, err := avro.MarshalJSONEncoding(schema, (*string)(nil))
+
+	require.NoError(t, err)
+	assert.Equal(t, `null`, string(got))
+}
+
+func TestMarshalJSONEncoding_NonFiniteFloats(t *testing.T) {
+	defer ConfigTeardown()
+
+	schema := avro.MustParse(`{"type": "array", "items": "double"}`)
+
+	got, err := avro.MarshalJSONEncoding(schema, []float64{math.NaN(), math.Inf(1), math.Inf(-1)})
+
+	require.NoError(t, err)
+	assert.Equal(t, `["NaN","Infinity","-Infinity"]`, string(got))
+}
+
+func TestMarshalJSONEncoding_Error(t *testing.T) {
+	defer ConfigTeardown()
+
+	schema := avro.MustParse(`{"type": "enum", "name": "en", "symbols": ["foo", "bar"]}`)
+
+	_, err := avro.MarshalJSONEncoding(schema, "baz")
+
+	assert.Error(t, err)
+}
+
+func TestUnmarshalJSONEncoding(t *testing.T) {
+	defer ConfigTeardown()
+
+	schema := avro.MustParse(testJSONSchema)
+	data := `{"b":"foo","a":27,"c":"\u0000\u007fÿ","d":"abé\u0001","e":"bar","f":{"string":"baz"},` +
+		`"g":[1,2],"h":{"x":true},"i":{"int":12},"j":1.5,"recs":[{"name":"one"}]}`
+
+	var got TestJSONRecord
+	err := avro.UnmarshalJSONEncoding(schema, []byte(data), &got)
+
+	require.NoError(t, err)
+	str := "baz"
+	want := TestJSONRecord{
+		A:    27,
+		B:    "foo",
+		C:    []byte{0x00, 0x7f, 0xff},
+		D:    [4]byte{'a', 'b', 0xe9, 0x01},
+		E:    "bar",
+		F:    &str,
+		G:    []int{1, 2},
+		H:    map[string]bool{"x": true},
+		I:    12,
+		J:    1.5,
+		Recs: []TestJSONSubItem{{Name: "one"}},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestUnmarshalJSONEncoding_FieldDefault(t *testing.T) {
+	defer ConfigTeardown()
+
+	schema := avro.MustParse(`{
+	"type": "record",
+	"name": "test",
+	"fields" : [
+		{"name": "a", "type": "long"},
+		{"name": "b", "type": "string", "default": "bar"}
+	]
+}`)
+
+	var got TestRecord
+	err := avro.UnmarshalJSONEncoding(schema, []byte(`{"a": 27}`), &got)
+
+	require.NoError(t, err)
+	assert.Equal(t, TestRecord{A: 27, B: "bar"}, got)
+}
+
+func TestUnmarshalJSONEncoding_ZeroCopyDoesNotReferencePooledBuffer(t *testing.T) {
+	defer ConfigTeardown()
+
+	api := avro.Config{ZeroCopy: true}.Freeze()
+	schema := avro.MustParse("bytes")
+
+	var first, second []byte
+	err := api.UnmarshalJSONEncoding(schema, []byte(`"hello"`), &first)
+	require.NoError(t, err)
+	err = api.UnmarshalJSONEncoding(schema, []byte(`"WORLD"`), &second)
+	require.NoError(t, err)
+
+	assert.Equal(t, []byte("hello"), first)
+	assert.Equal(t, []byte("WORLD"), second)
+}
+
+func TestUnmarshalJSONEncoding_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		data   string
+	}{
+		{
+			name:   "invalid json",
+			schema: `"int"`,
+			data:   `{`,
+		},
+		{
+			name:   "int overflow",
+			schema: `"int"`,
+			data:   `2147483648`,
+		},
+		{
+			name:   "wrong type",
+			schema: `"string"`,
+			data:   `1`,
+		},
+		{
+			name:   "bytes out of range",
+			schema: `"bytes"`,
+			data:   `"Ā"`,
+		},
+		{
+			name:   "fixed wrong size",
+			schema: `{"type": "fixed", "name": "fix", "size": 4}`,
+			data:   `"abc"`,
+		},
+		{
+			name:   "unknown enum symbol",
+			schema: `{"type": "enum", "name": "en", "symbols": ["foo"]}`,
+			data:   `"bar"`,
+		},
+		{
+			name:   "unknown union branch",
+			schema: `["null", "string"]`,
+			data:   `{"int": 1}`,
+		},
+		{
+			name:   "null not in union",
+			schema: `["int", "string"]`,
+			data:   `null`,
+		},
+		{
+			name:   "missing required field",
+			schema: `{"type": "record", "name": "test", "fields": [{"name": "a", "type": "long"}]}`,
+			data:   `{}`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			defer ConfigTeardown()
+
+			schema := avro.MustParse(test.schema)
+
+			var got any
+			err := avro.UnmarshalJSONEncoding(schema, []byte(test.data), &got)
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestJSONEncoderDecoder_RoundTrip(t *testing.T) {
+	defer ConfigTeardown()
+
+	buf := &bytes.Buffer{}
+	enc, err := avro.NewJSONEncoder(testJSONSchema, buf)
+	require.NoError(t, err)
+
+	in := []TestJSONRecord{
+		{A: 1, B: "one", C: []byte{}, E: "foo", H: map[string]bool{}},
+		{A: 2, B: "two", C: []byte{1}, E: "bar", G: []int{3}, H: map[string]bool{"y": false}},
+	}
+	for _, v := range in {
+		require.NoError(t, enc.Encode(v))
+	}
+
+	dec, err := avro.NewJSONDecoder(testJSONSchema, buf)
+	require.NoError(t, err)
+
+	var got []TestJSONRecord
+	for {
+		var rec TestJSONRecord
+		err = dec.Decode(&rec)
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		got = append(got, rec)
+	}
+	assert.Equal(t, in, got)
+}
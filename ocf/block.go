@@ -0,0 +1,138 @@
+package ocf
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/kjuulh/avro/v2"
+)
+
+// Block is a data block of a container file, as stored in the file.
+type Block struct {
+	// Count is the number of records in the block.
+	Count int64
+	// Data is the compressed data of the block.
+	Data []byte
+}
+
+// BlockReader reads the data blocks of a container file without
+// decompressing or decoding them.
+type BlockReader struct {
+	r      *seekReader
+	schema avro.Schema
+	meta   map[string][]byte
+	sync   [16]byte
+
+	block Block
+	err   error
+}
+
+// NewBlockReader returns a new block reader that reads from r.
+func NewBlockReader(r io.Reader) (*BlockReader, error) {
+	sr := &seekReader{br: bufio.NewReader(r)}
+
+	h, err := sr.readHeader()
+	if err != nil {
+		return nil, fmt.Errorf("decoder: %w", err)
+	}
+
+	return &BlockReader{
+		r:      sr,
+		schema: h.Schema,
+		meta:   h.Meta,
+		sync:   h.Sync,
+	}, nil
+}
+
+// Metadata returns the header metadata.
+func (r *BlockReader) Metadata() map[string][]byte {
+	return r.meta
+}
+
+// Schema returns the schema of the file.
+func (r *BlockReader) Schema() avro.Schema {
+	return r.schema
+}
+
+// Codec returns the name of the compression codec of the file.
+func (r *BlockReader) Codec() CodecName {
+	return codecNameOf(r.meta)
+}
+
+// Next reads the next block, returning false when there are no more blocks
+// or an error occurred.
+func (r *BlockReader) Next() bool {
+	if r.err != nil {
+		return false
+	}
+
+	count, size, err := r.r.readBlockHeader()
+	if err != nil {
+		r.err = err
+		return false
+	}
+	data, err := r.r.readN(size)
+	if err != nil {
+		r.err = unexpectedEOF(err)
+		return false
+	}
+	if err = r.r.readSync(r.sync); err != nil {
+		r.err = err
+		return false
+	}
+
+	r.block = Block{Count: count, Data: data}
+	return true
+}
+
+// Block returns the block read by Next.
+func (r *BlockReader) Block() Block {
+	return r.block
+}
+
+// Error returns the last reader error.
+func (r *BlockReader) Error() error {
+	if errors.Is(r.err, io.EOF) {
+		return nil
+	}
+	if r.err != nil {
+		return fmt.Errorf("decoder: %w", r.err)
+	}
+	return nil
+}
+
+// WriteBlock writes a block to the file after any pending values, without
+// compressing it. The block data must be compressed with the codec of the encoder.
+func (e *Encoder) WriteBlock(b Block) error {
+	if b.Count < 0 {
+		return errors.New("block count cannot be negative")
+	}
+	if err := e.Flush(); err != nil {
+		return err
+	}
+
+	return e.writeBlock(b.Count, b.Data)
+}
+
+// CopyBlocks writes the remaining blocks of r to the file without
+// decompressing or decoding them, returning the number of blocks written.
+// The schema and codec of r must match the encoder.
+func (e *Encoder) CopyBlocks(r *BlockReader) (int, error) {
+	if r.schema.Fingerprint() != e.schema.Fingerprint() {
+		return 0, errors.New("block reader schema does not match the encoder schema")
+	}
+	if codec := r.Codec(); codec != e.codecName {
+		return 0, fmt.Errorf("block reader codec %s does not match the encoder codec %s", codec, e.codecName)
+	}
+
+	var n int
+	for r.Next() {
+		if err := e.WriteBlock(r.Block()); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, r.Error()
+}
@@ -0,0 +1,147 @@
+package ocf_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kjuulh/avro/v2"
+	"github.com/kjuulh/avro/v2/ocf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBlockReader_InvalidHeader(t *testing.T) {
+	_, err := ocf.NewBlockReader(bytes.NewReader([]byte{'O', 'b', 'j', 1, 0x02}))
+
+	assert.Error(t, err)
+}
+
+func TestNewBlockReader_OversizedMetadata(t *testing.T) {
+	_, err := ocf.NewBlockReader(bytes.NewReader(oversizedHeader()))
+
+	assert.ErrorContains(t, err, "size is greater than the maximum")
+}
+
+func TestBlockReader(t *testing.T) {
+	data := seekFile(t, 5, ocf.WithCodec(ocf.Deflate))
+
+	r, err := ocf.NewBlockReader(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	assert.Equal(t, ocf.Deflate, r.Codec())
+	assert.Equal(t, avro.MustParse(seekSchema).Fingerprint(), r.Schema().Fingerprint())
+	assert.Equal(t, []byte("deflate"), r.Metadata()["avro.codec"])
+
+	var counts []int64
+	for r.Next() {
+		blk := r.Block()
+		assert.NotEmpty(t, blk.Data)
+		counts = append(counts, blk.Count)
+	}
+	require.NoError(t, r.Error())
+	assert.Equal(t, []int64{2, 2, 1}, counts)
+}
+
+func TestBlockReader_InvalidSync(t *testing.T) {
+	data := seekFile(t, 5)
+	data[len(data)-1]++
+
+	r, err := ocf.NewBlockReader(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	var n int
+	for r.Next() {
+		n++
+	}
+
+	assert.Equal(t, 2, n)
+	assert.Error(t, r.Error())
+}
+
+func TestEncoder_CopyBlocks(t *testing.T) {
+	first := seekFile(t, 3, ocf.WithCodec(ocf.Snappy))
+	second := seekFile(t, 2, ocf.WithCodec(ocf.Snappy))
+
+	buf := &bytes.Buffer{}
+	enc, err := ocf.NewEncoder(seekSchema, buf, ocf.WithCodec(ocf.Snappy))
+	require.NoError(t, err)
+
+	var blocks int
+	for _, data := range [][]byte{first, second} {
+		r, err := ocf.NewBlockReader(bytes.NewReader(data))
+		require.NoError(t, err)
+
+		n, err := enc.CopyBlocks(r)
+		require.NoError(t, err)
+		blocks += n
+	}
+	require.NoError(t, enc.Close())
+
+	assert.Equal(t, 3, blocks)
+	dec, err := ocf.NewSeekableDecoder(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, []int64{0, 1, 2, 0, 1}, readSeekRecords(t, dec))
+}
+
+func TestEncoder_CopyBlocksSchemaMismatch(t *testing.T) {
+	r, err := ocf.NewBlockReader(bytes.NewReader(seekFile(t, 3)))
+	require.NoError(t, err)
+
+	enc, err := ocf.NewEncoder(`"long"`, &bytes.Buffer{})
+	require.NoError(t, err)
+
+	_, err = enc.CopyBlocks(r)
+
+	assert.Error(t, err)
+}
+
+func TestEncoder_CopyBlocksCodecMismatch(t *testing.T) {
+	r, err := ocf.NewBlockReader(bytes.NewReader(seekFile(t, 3, ocf.WithCodec(ocf.Deflate))))
+	require.NoError(t, err)
+
+	enc, err := ocf.NewEncoder(seekSchema, &bytes.Buffer{})
+	require.NoError(t, err)
+
+	_, err = enc.CopyBlocks(r)
+
+	assert.Error(t, err)
+}
+
+func TestEncoder_WriteBlock(t *testing.T) {
+	r, err := ocf.NewBlockReader(bytes.NewReader(seekFile(t, 5)))
+	require.NoError(t, err)
+
+	bufs := []*bytes.Buffer{{}, {}}
+	var encs []*ocf.Encoder
+	for _, buf := range bufs {
+		enc, err := ocf.NewEncoder(seekSchema, buf, ocf.WithBlockLength(10))
+		require.NoError(t, err)
+		require.NoError(t, enc.Encode(SeekRecord{ID: 100}))
+		encs = append(encs, enc)
+	}
+
+	var i int
+	for r.Next() {
+		require.NoError(t, encs[i%2].WriteBlock(r.Block()))
+		i++
+	}
+	require.NoError(t, r.Error())
+
+	want := [][]int64{{100, 0, 1, 4}, {100, 2, 3}}
+	for i, enc := range encs {
+		require.NoError(t, enc.Close())
+
+		dec, err := ocf.NewSeekableDecoder(bytes.NewReader(bufs[i].Bytes()))
+		require.NoError(t, err)
+		assert.Equal(t, want[i], readSeekRecords(t, dec))
+	}
+}
+
+func TestEncoder_WriteBlockNegativeCount(t *testing.T) {
+	enc, err := ocf.NewEncoder(seekSchema, &bytes.Buffer{})
+	require.NoError(t, err)
+
+	err = enc.WriteBlock(ocf.Block{Count: -1})
+
+	assert.Error(t, err)
+}
@@ -2,15 +2,18 @@ package ocf
 
 import (
 	"bytes"
+	"compress/bzip2"
 	"compress/flate"
 	"encoding/binary"
 	"errors"
 	"fmt"
 	"hash/crc32"
 	"io"
+	"sync"
 
 	"github.com/golang/snappy"
 	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
 )
 
 // CodecName represents a compression codec name.
@@ -22,28 +25,90 @@ const (
 	Deflate   CodecName = "deflate"
 	Snappy    CodecName = "snappy"
 	ZStandard CodecName = "zstandard"
+	BZip2     CodecName = "bzip2"
+	XZ        CodecName = "xz"
 )
 
-func resolveCodec(name CodecName, lvl int) (Codec, error) {
-	switch name {
-	case Null, "":
-		return &NullCodec{}, nil
+// CodecFactory returns a codec using the given compression level.
+// A level of -1 means the codec default.
+type CodecFactory func(lvl int) (Codec, error)
+
+var (
+	codecsMu sync.RWMutex
+	codecs   = map[CodecName]CodecFactory{
+		Null:      func(int) (Codec, error) { return &NullCodec{}, nil },
+		Deflate:   func(lvl int) (Codec, error) { return &DeflateCodec{compLvl: lvl}, nil },
+		Snappy:    func(int) (Codec, error) { return &SnappyCodec{}, nil },
+		ZStandard: func(lvl int) (Codec, error) { return &ZStandardCodec{compLvl: lvl}, nil },
+		BZip2:     func(int) (Codec, error) { return &BZip2Codec{}, nil },
+		XZ:        func(int) (Codec, error) { return &XZCodec{}, nil },
+	}
+)
+
+// RegisterCodec registers a codec factory with the given name, replacing
+// any codec already registered with that name.
+//
+// Registered codecs are used by both the Decoder and the Encoder. The factory
+// is called once per concurrent worker, so the codecs it returns need not
+// be safe for concurrent use.
+func RegisterCodec(name CodecName, factory CodecFactory) {
+	if name == "" {
+		panic("ocf: codec name cannot be empty")
+	}
+	if factory == nil {
+		panic("ocf: codec factory cannot be nil")
+	}
 
-	case Deflate:
-		return &DeflateCodec{compLvl: lvl}, nil
+	codecsMu.Lock()
+	defer codecsMu.Unlock()
 
-	case Snappy:
-		return &SnappyCodec{}, nil
+	codecs[name] = factory
+}
 
-	case ZStandard:
-		return &ZStandardCodec{}, nil
+func resolveCodec(name CodecName, lvl int) (Codec, error) {
+	if name == "" {
+		name = Null
+	}
 
-	default:
+	codecsMu.RLock()
+	factory, ok := codecs[name]
+	codecsMu.RUnlock()
+	if !ok {
 		return nil, fmt.Errorf("unknown codec %s", name)
 	}
+	return factory(lvl)
+}
+
+// DecodeOnlyCodec is implemented by codecs that only support decoding.
+// An Encoder cannot be created with a decode only codec.
+type DecodeOnlyCodec interface {
+	Codec
+
+	// DecodeOnly marks the codec as only supporting decoding.
+	DecodeOnly()
+}
+
+func resolveEncoderCodec(name CodecName, lvl int) (Codec, error) {
+	codec, err := resolveCodec(name, lvl)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkEncoderCodec(name, codec); err != nil {
+		return nil, err
+	}
+	return codec, nil
+}
+
+func checkEncoderCodec(name CodecName, codec Codec) error {
+	if _, ok := codec.(DecodeOnlyCodec); ok {
+		return fmt.Errorf("codec %s does not support encoding", name)
+	}
+	return nil
 }
 
 // Codec represents a compression codec.
+//
+// A Codec is not used concurrently by the Encoder or Decoder.
 type Codec interface {
 	// Decode decodes the given bytes.
 	Decode([]byte) ([]byte, error)
@@ -51,6 +116,23 @@ type Codec interface {
 	Encode([]byte) []byte
 }
 
+// EncodeErrCodec is implemented by codecs that can fail to encode.
+// The Encoder uses EncodeErr instead of Encode for such codecs.
+type EncodeErrCodec interface {
+	Codec
+
+	// EncodeErr encodes the given bytes, returning an error if they could not be encoded.
+	EncodeErr([]byte) ([]byte, error)
+}
+
+// encode encodes the bytes with the codec, reporting the encoding error of an EncodeErrCodec.
+func encode(codec Codec, b []byte) ([]byte, error) {
+	if c, ok := codec.(EncodeErrCodec); ok {
+		return c.EncodeErr(b)
+	}
+	return codec.Encode(b), nil
+}
+
 // NullCodec is a no op codec.
 type NullCodec struct{}
 
@@ -84,13 +166,27 @@ func (c *DeflateCodec) Decode(b []byte) ([]byte, error) {
 
 // Encode encodes the given bytes.
 func (c *DeflateCodec) Encode(b []byte) []byte {
+	data, _ := c.EncodeErr(b)
+	return data
+}
+
+// EncodeErr encodes the given bytes, returning an error if they could not be encoded.
+func (c *DeflateCodec) EncodeErr(b []byte) ([]byte, error) {
 	data := bytes.NewBuffer(make([]byte, 0, len(b)))
 
-	w, _ := flate.NewWriter(data, c.compLvl)
-	_, _ = w.Write(b)
-	_ = w.Close()
+	w, err := flate.NewWriter(data, c.compLvl)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = w.Write(b); err != nil {
+		_ = w.Close()
+		return nil, err
+	}
+	if err = w.Close(); err != nil {
+		return nil, err
+	}
 
-	return data.Bytes()
+	return data.Bytes(), nil
 }
 
 // SnappyCodec is a snappy compression codec.
@@ -127,7 +223,9 @@ func (*SnappyCodec) Encode(b []byte) []byte {
 }
 
 // ZStandardCodec is a zstandard compression codec.
-type ZStandardCodec struct{}
+type ZStandardCodec struct {
+	compLvl int
+}
 
 // Decode decodes the given bytes.
 func (*ZStandardCodec) Decode(b []byte) ([]byte, error) {
@@ -138,9 +236,83 @@ func (*ZStandardCodec) Decode(b []byte) ([]byte, error) {
 }
 
 // Encode encodes the given bytes.
-func (*ZStandardCodec) Encode(b []byte) []byte {
-	enc, _ := zstd.NewWriter(nil)
+func (c *ZStandardCodec) Encode(b []byte) []byte {
+	data, _ := c.EncodeErr(b)
+	return data
+}
+
+// EncodeErr encodes the given bytes, returning an error if they could not be encoded.
+func (c *ZStandardCodec) EncodeErr(b []byte) ([]byte, error) {
+	var opts []zstd.EOption
+	if c.compLvl > 0 {
+		opts = append(opts, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(c.compLvl)))
+	}
+
+	enc, err := zstd.NewWriter(nil, opts...)
+	if err != nil {
+		return nil, err
+	}
 	defer func() { _ = enc.Close() }()
 
-	return enc.EncodeAll(b, nil)
+	return enc.EncodeAll(b, nil), nil
+}
+
+// BZip2Codec is a bzip2 compression codec.
+//
+// The codec only supports decoding.
+type BZip2Codec struct{}
+
+// Decode decodes the given bytes.
+func (*BZip2Codec) Decode(b []byte) ([]byte, error) {
+	return io.ReadAll(bzip2.NewReader(bytes.NewReader(b)))
+}
+
+// Encode is not supported and returns nil.
+func (*BZip2Codec) Encode([]byte) []byte {
+	return nil
+}
+
+// EncodeErr is not supported and returns an error.
+func (*BZip2Codec) EncodeErr([]byte) ([]byte, error) {
+	return nil, errors.New("bzip2 encoding is not supported")
+}
+
+// DecodeOnly marks the codec as only supporting decoding.
+func (*BZip2Codec) DecodeOnly() {}
+
+// XZCodec is a xz compression codec.
+type XZCodec struct{}
+
+// Decode decodes the given bytes.
+func (*XZCodec) Decode(b []byte) ([]byte, error) {
+	r, err := xz.NewReader(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(r)
+}
+
+// Encode encodes the given bytes.
+func (c *XZCodec) Encode(b []byte) []byte {
+	data, _ := c.EncodeErr(b)
+	return data
+}
+
+// EncodeErr encodes the given bytes, returning an error if they could not be encoded.
+func (*XZCodec) EncodeErr(b []byte) ([]byte, error) {
+	data := bytes.NewBuffer(make([]byte, 0, len(b)))
+
+	w, err := xz.NewWriter(data)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = w.Write(b); err != nil {
+		_ = w.Close()
+		return nil, err
+	}
+	if err = w.Close(); err != nil {
+		return nil, err
+	}
+
+	return data.Bytes(), nil
 }
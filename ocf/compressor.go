@@ -0,0 +1,110 @@
+package ocf
+
+import "sync"
+
+// compressedBlock is the result of compressing a block.
+type compressedBlock struct {
+	count int64
+	data  []byte
+	err   error
+}
+
+type compressJob struct {
+	count int64
+	data  []byte
+	res   chan<- compressedBlock
+}
+
+// blockCompressor compresses blocks for the encoder on a pool of workers,
+// writing them in the order they were submitted, with at most n blocks in flight.
+type blockCompressor struct {
+	jobs    chan compressJob
+	blocks  chan chan compressedBlock
+	pending sync.WaitGroup
+
+	mu  sync.Mutex
+	err error
+}
+
+// newBlockCompressor returns a compressor for the encoder. Each worker
+// compresses with its own codec, as codecs need not be safe for concurrent use.
+func newBlockCompressor(e *Encoder, n int) (*blockCompressor, error) {
+	workers := make([]Codec, n)
+	for i := range workers {
+		codec, err := resolveCodec(e.codecName, e.codecLvl)
+		if err != nil {
+			return nil, err
+		}
+		workers[i] = codec
+	}
+
+	c := &blockCompressor{
+		jobs:   make(chan compressJob, n),
+		blocks: make(chan chan compressedBlock, n),
+	}
+
+	for _, codec := range workers {
+		codec := codec
+		go func() {
+			for job := range c.jobs {
+				data, err := encode(codec, job.data)
+				job.res <- compressedBlock{count: job.count, data: data, err: err}
+			}
+		}()
+	}
+
+	go func() {
+		for res := range c.blocks {
+			blk := <-res
+			if c.error() == nil {
+				err := blk.err
+				if err == nil {
+					err = e.writeBlock(blk.count, blk.data)
+				}
+				if err != nil {
+					c.setError(err)
+				}
+			}
+			c.pending.Done()
+		}
+	}()
+
+	return c, nil
+}
+
+// submit queues a block to be compressed and written,
+// blocking while n blocks are in flight.
+func (c *blockCompressor) submit(count int64, data []byte) {
+	c.pending.Add(1)
+
+	res := make(chan compressedBlock, 1)
+	c.blocks <- res
+	c.jobs <- compressJob{count: count, data: data, res: res}
+}
+
+// wait waits for all submitted blocks to be written.
+func (c *blockCompressor) wait() error {
+	c.pending.Wait()
+	return c.error()
+}
+
+func (c *blockCompressor) close() {
+	close(c.jobs)
+	close(c.blocks)
+}
+
+func (c *blockCompressor) error() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.err
+}
+
+func (c *blockCompressor) setError(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.err == nil {
+		c.err = err
+	}
+}
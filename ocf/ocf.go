@@ -4,7 +4,9 @@
 package ocf
 
 import (
+	"bufio"
 	"bytes"
+	"context"
 	"crypto/rand"
 	"errors"
 	"fmt"
@@ -40,38 +42,137 @@ type Header struct {
 	Sync  [16]byte          `avro:"sync"`
 }
 
+type decoderConfig struct {
+	Concurrency  int
+	ReaderSchema avro.Schema
+	OnBlockError func(*BlockError)
+}
+
+// DecoderFunc represents a configuration function for Decoder.
+type DecoderFunc func(cfg *decoderConfig)
+
+// WithDecoderConcurrency sets the number of blocks the decoder reads ahead
+// and decompresses concurrently. The values in a block are decoded in order
+// by Decode.
+//
+// Each concurrent worker uses its own codec, created from the registered CodecFactory.
+// A concurrent decoder should be closed when it is no longer used.
+func WithDecoderConcurrency(n int) DecoderFunc {
+	return func(cfg *decoderConfig) {
+		cfg.Concurrency = n
+	}
+}
+
+// WithReaderSchema sets the schema values are decoded with. The writer schema
+// of the file is resolved into the reader schema, which must be compatible
+// with it.
+func WithReaderSchema(schema avro.Schema) DecoderFunc {
+	return func(cfg *decoderConfig) {
+		cfg.ReaderSchema = schema
+	}
+}
+
+// WithCorruptBlockHandler enables recovery from corrupt blocks.
+//
+// When a block has an invalid header or sync marker, cannot be decompressed
+// or holds a value that cannot be decoded, fn is called with the block and
+// the decoder resynchronises at the next sync marker, skipping the rest of
+// the block. Decode still returns the error of a value that cannot be decoded.
+func WithCorruptBlockHandler(fn func(*BlockError)) DecoderFunc {
+	return func(cfg *decoderConfig) {
+		cfg.OnBlockError = fn
+	}
+}
+
+// BlockError describes a corrupt block skipped by a decoder.
+type BlockError struct {
+	// Offset is the byte offset of the start of the block.
+	Offset int64
+	// Count is the number of records in the block, as read from its header.
+	Count int64
+	// Err is the error the block failed with.
+	Err error
+}
+
+// Error returns the error message.
+func (e *BlockError) Error() string {
+	return fmt.Sprintf("decoder: corrupt block at offset %d: %v", e.Offset, e.Err)
+}
+
+// Unwrap returns the error the block failed with.
+func (e *BlockError) Unwrap() error {
+	return e.Err
+}
+
 // Decoder reads and decodes Avro values from a container file.
 type Decoder struct {
-	reader      *avro.Reader
-	resetReader *bytesx.ResetReader
-	decoder     *avro.Decoder
-	meta        map[string][]byte
-	sync        [16]byte
+	r            *seekReader
+	resetReader  *bytesx.ResetReader
+	decoder      *avro.Decoder
+	readerSchema avro.Schema
+	writerSchema avro.Schema
+	meta         map[string][]byte
+	sync         [16]byte
 
 	codec Codec
 
+	onBlockError func(*BlockError)
+	block        rawBlock
+
 	count int64
+	err   error
+
+	readAhead *blockReadAhead
 }
 
 // NewDecoder returns a new decoder that reads from reader r.
-func NewDecoder(r io.Reader) (*Decoder, error) {
-	reader := avro.NewReader(r, 1024)
+func NewDecoder(r io.Reader, opts ...DecoderFunc) (*Decoder, error) {
+	return newDecoder(r, nil, 0, opts)
+}
 
-	h, err := readHeader(reader)
+// newDecoder returns a new decoder that reads from r. If rs is not nil,
+// reading starts at the block at offset in rs after reading the header from r.
+func newDecoder(r io.Reader, rs io.ReadSeeker, offset int64, opts []DecoderFunc) (*Decoder, error) {
+	var cfg decoderConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	sr := &seekReader{br: bufio.NewReader(r)}
+
+	h, err := sr.readHeader()
 	if err != nil {
 		return nil, fmt.Errorf("decoder: %w", err)
 	}
 
-	decReader := bytesx.NewResetReader([]byte{})
+	d := &Decoder{
+		r:            sr,
+		resetReader:  bytesx.NewResetReader([]byte{}),
+		readerSchema: cfg.ReaderSchema,
+		writerSchema: h.Schema,
+		meta:         h.Meta,
+		sync:         h.Sync,
+		codec:        h.Codec,
+		onBlockError: cfg.OnBlockError,
+	}
+	d.decoder, err = d.newValueDecoder()
+	if err != nil {
+		return nil, fmt.Errorf("decoder: %w", err)
+	}
 
-	return &Decoder{
-		reader:      reader,
-		resetReader: decReader,
-		decoder:     avro.NewDecoderForSchema(h.Schema, decReader),
-		meta:        h.Meta,
-		sync:        h.Sync,
-		codec:       h.Codec,
-	}, nil
+	if rs != nil {
+		sr.rs = rs
+		if err = sr.seek(offset); err != nil {
+			return nil, fmt.Errorf("decoder: %w", err)
+		}
+	}
+
+	if cfg.Concurrency > 1 {
+		if d.readAhead, err = newBlockReadAhead(d, cfg.Concurrency); err != nil {
+			return nil, fmt.Errorf("decoder: %w", err)
+		}
+	}
+	return d, nil
 }
 
 // Metadata returns the header metadata.
@@ -81,16 +182,29 @@ func (d *Decoder) Metadata() map[string][]byte {
 
 // HasNext determines if there is another value to read.
 func (d *Decoder) HasNext() bool {
-	if d.count <= 0 {
-		count := d.readBlock()
-		d.count = count
-	}
+	return d.hasNext(context.Background())
+}
 
-	if d.reader.Error != nil {
-		return false
+// hasNext determines if there is another value to read. Waiting for a block
+// read ahead stops when ctx is done.
+func (d *Decoder) hasNext(ctx context.Context) bool {
+	if d.readAhead != nil {
+		for d.count <= 0 {
+			if d.readAhead.err != nil || ctx.Err() != nil {
+				return false
+			}
+			d.count = d.readAhead.next(ctx, d)
+		}
+		return true
 	}
 
-	return d.count > 0
+	for d.count <= 0 {
+		if d.err != nil {
+			return false
+		}
+		d.count = d.readBlock()
+	}
+	return true
 }
 
 // Decode reads the next Avro encoded value from its input and stores it in the value pointed to by v.
@@ -101,41 +215,158 @@ func (d *Decoder) Decode(v any) error {
 
 	d.count--
 
-	return d.decoder.Decode(v)
+	err := d.decoder.Decode(v)
+	if err == nil || d.onBlockError == nil || !d.blockCorrupt() {
+		return err
+	}
+
+	// The value reader holds the error and the rest of the block,
+	// replace it and skip to the next block.
+	blkErr := &BlockError{Offset: d.block.offset, Count: d.block.count, Err: err}
+	d.onBlockError(blkErr)
+	d.count = 0
+	d.decoder, _ = d.newValueDecoder()
+	return blkErr
 }
 
 // Error returns the last reader error.
 func (d *Decoder) Error() error {
-	if errors.Is(d.reader.Error, io.EOF) {
+	var err error
+	if d.readAhead != nil {
+		err = d.readAhead.err
+	} else {
+		err = d.err
+	}
+
+	if errors.Is(err, io.EOF) {
 		return nil
 	}
 
-	return d.reader.Error
+	return err
+}
+
+// Close stops the read ahead of a concurrent decoder.
+// It does not close the underlying reader.
+func (d *Decoder) Close() error {
+	if d.readAhead != nil {
+		d.readAhead.close()
+	}
+	return nil
+}
+
+// blockCorrupt determines if the data of the current block cannot be read with
+// the writer schema, as opposed to a value that cannot be decoded into the given type.
+func (d *Decoder) blockCorrupt() bool {
+	r := avro.NewReader(nil, 0).Reset(d.block.data)
+	for i := int64(0); i < d.block.count; i++ {
+		var v any
+		r.ReadVal(d.writerSchema, &v)
+		if r.Error != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *Decoder) newValueDecoder() (*avro.Decoder, error) {
+	if d.readerSchema == nil {
+		return avro.NewDecoderForSchema(d.writerSchema, d.resetReader), nil
+	}
+	return avro.NewDecoderWithSchemas(d.readerSchema, d.writerSchema, d.resetReader)
 }
 
 func (d *Decoder) readBlock() int64 {
-	count := d.reader.ReadLong()
-	size := d.reader.ReadLong()
+	blk, err := d.readRawBlock()
+	if err != nil {
+		d.err = err
+		return 0
+	}
 
-	if count > 0 {
-		data := make([]byte, size)
-		d.reader.Read(data)
+	if blk.err == nil && blk.count > 0 {
+		blk.data, blk.err = d.codec.Decode(blk.data)
+	}
 
-		data, err := d.codec.Decode(data)
-		if err != nil {
-			d.reader.Error = err
+	count, err := d.loadBlock(blk)
+	if err != nil {
+		d.err = err
+	}
+	return count
+}
+
+// loadBlock resets the value reader to the decompressed block data, returning
+// the record count of the block. A corrupt block is reported in recovery mode,
+// otherwise its error is returned.
+func (d *Decoder) loadBlock(blk rawBlock) (int64, error) {
+	if blk.err != nil {
+		if d.onBlockError == nil {
+			return 0, blk.err
 		}
+		d.onBlockError(&BlockError{Offset: blk.offset, Count: blk.count, Err: blk.err})
+		return 0, nil
+	}
+
+	d.block = rawBlock{offset: blk.offset, count: blk.count, data: blk.data}
+	d.resetReader.Reset(blk.data)
+	return blk.count, nil
+}
 
-		d.resetReader.Reset(data)
+// rawBlock is a block as read from the file.
+type rawBlock struct {
+	offset int64
+	count  int64
+	data   []byte
+
+	// err is set on a corrupt block in recovery mode.
+	err error
+}
+
+// readRawBlock reads the next block with its compressed data.
+//
+// In recovery mode, a block that cannot be read is returned with its error
+// after skipping to the next sync marker.
+func (d *Decoder) readRawBlock() (rawBlock, error) {
+	blk := rawBlock{offset: d.r.pos}
+
+	count, size, err := d.r.readBlockHeader()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return blk, io.EOF
+		}
+		return d.skipCorrupt(blk, nil, err)
+	}
+	blk.count = count
+
+	data, err := d.r.readN(size)
+	if err != nil {
+		return d.skipCorrupt(blk, data, unexpectedEOF(err))
 	}
 
 	var sync [16]byte
-	d.reader.Read(sync[:])
-	if d.sync != sync && !errors.Is(d.reader.Error, io.EOF) {
-		d.reader.Error = errors.New("decoder: invalid block")
+	n, err := io.ReadFull(d.r, sync[:])
+	if err != nil {
+		return d.skipCorrupt(blk, append(data, sync[:n]...), unexpectedEOF(err))
+	}
+	if sync != d.sync {
+		return d.skipCorrupt(blk, append(data, sync[:]...), errors.New("invalid block"))
 	}
 
-	return count
+	blk.data = data
+	return blk, nil
+}
+
+// skipCorrupt handles a block that cannot be read. In recovery mode, the
+// reader is positioned after the next sync marker, searching the bytes b
+// read from the block first.
+func (d *Decoder) skipCorrupt(blk rawBlock, b []byte, err error) (rawBlock, error) {
+	if d.onBlockError == nil {
+		return blk, fmt.Errorf("decoder: %w", err)
+	}
+
+	blk.err = err
+	if err = d.r.skipSync(d.sync, b); err != nil && !errors.Is(err, io.EOF) {
+		return blk, fmt.Errorf("decoder: %w", err)
+	}
+	return blk, nil
 }
 
 type encoderConfig struct {
@@ -145,6 +376,7 @@ type encoderConfig struct {
 	Metadata         map[string][]byte
 	Sync             [16]byte
 	EncodingConfig   avro.API
+	Concurrency      int
 }
 
 // EncoderFunc represents an configuration function for Encoder.
@@ -173,6 +405,14 @@ func WithCompressionLevel(compLvl int) EncoderFunc {
 	}
 }
 
+// WithCodecLevel sets the compression level passed to the codec of the encoder.
+// Unlike WithCompressionLevel, the codec is not changed.
+func WithCodecLevel(lvl int) EncoderFunc {
+	return func(cfg *encoderConfig) {
+		cfg.CodecCompression = lvl
+	}
+}
+
 // WithMetadata sets the metadata on the encoder header.
 func WithMetadata(meta map[string][]byte) EncoderFunc {
 	return func(cfg *encoderConfig) {
@@ -194,27 +434,42 @@ func WithEncodingConfig(wCfg avro.API) EncoderFunc {
 	}
 }
 
+// WithEncoderConcurrency sets the number of blocks the encoder compresses
+// concurrently. Compressed blocks are written in order. An error writing
+// a block is returned by a later call to Encode, Flush or Close.
+//
+// Each concurrent worker uses its own codec, created from the registered CodecFactory.
+func WithEncoderConcurrency(n int) EncoderFunc {
+	return func(cfg *encoderConfig) {
+		cfg.Concurrency = n
+	}
+}
+
 // Encoder writes Avro container file to an output stream.
 type Encoder struct {
 	writer  *avro.Writer
 	buf     *bytes.Buffer
 	encoder *avro.Encoder
+	schema  avro.Schema
 	sync    [16]byte
 
-	codec Codec
+	codec     Codec
+	codecName CodecName
+	codecLvl  int
 
 	blockLength int
 	count       int
+
+	compressor *blockCompressor
 }
 
 // NewEncoder returns a new encoder that writes to w using schema s.
 //
-// If the writer is an existing ocf file, it will append data using the
-// existing schema.
+// If the writer is an io.ReadWriteSeeker holding an existing ocf file, data is
+// appended to it. The schema and any configured codec must match the file.
 func NewEncoder(s string, w io.Writer, opts ...EncoderFunc) (*Encoder, error) {
 	cfg := encoderConfig{
 		BlockLength:      100,
-		CodecName:        Null,
 		CodecCompression: -1,
 		Metadata:         map[string][]byte{},
 		EncodingConfig:   avro.DefaultConfig,
@@ -226,33 +481,14 @@ func NewEncoder(s string, w io.Writer, opts ...EncoderFunc) (*Encoder, error) {
 	switch file := w.(type) {
 	case nil:
 		return nil, errors.New("writer cannot be nil")
-	case *os.File:
-		info, err := file.Stat()
+	case io.ReadWriteSeeker:
+		size, err := fileSize(file)
 		if err != nil {
 			return nil, err
 		}
 
-		if info.Size() > 0 {
-			reader := avro.NewReader(file, 1024)
-			h, err := readHeader(reader)
-			if err != nil {
-				return nil, err
-			}
-			if err = skipToEnd(reader, h.Sync); err != nil {
-				return nil, err
-			}
-
-			writer := avro.NewWriter(w, 512, avro.WithWriterConfig(cfg.EncodingConfig))
-			buf := &bytes.Buffer{}
-			e := &Encoder{
-				writer:      writer,
-				buf:         buf,
-				encoder:     cfg.EncodingConfig.NewEncoder(h.Schema, buf),
-				sync:        h.Sync,
-				codec:       h.Codec,
-				blockLength: cfg.BlockLength,
-			}
-			return e, nil
+		if size > 0 {
+			return newAppendEncoder(s, file, cfg)
 		}
 	}
 
@@ -261,6 +497,9 @@ func NewEncoder(s string, w io.Writer, opts ...EncoderFunc) (*Encoder, error) {
 		return nil, err
 	}
 
+	if cfg.CodecName == "" {
+		cfg.CodecName = Null
+	}
 	cfg.Metadata[schemaKey] = []byte(schema.String())
 	cfg.Metadata[codecKey] = []byte(cfg.CodecName)
 	header := Header{
@@ -272,7 +511,7 @@ func NewEncoder(s string, w io.Writer, opts ...EncoderFunc) (*Encoder, error) {
 		_, _ = rand.Read(header.Sync[:])
 	}
 
-	codec, err := resolveCodec(cfg.CodecName, cfg.CodecCompression)
+	codec, err := resolveEncoderCodec(cfg.CodecName, cfg.CodecCompression)
 	if err != nil {
 		return nil, err
 	}
@@ -288,13 +527,102 @@ func NewEncoder(s string, w io.Writer, opts ...EncoderFunc) (*Encoder, error) {
 		writer:      writer,
 		buf:         buf,
 		encoder:     cfg.EncodingConfig.NewEncoder(schema, buf),
+		schema:      schema,
 		sync:        header.Sync,
 		codec:       codec,
+		codecName:   cfg.CodecName,
+		codecLvl:    cfg.CodecCompression,
 		blockLength: cfg.BlockLength,
 	}
+	if cfg.Concurrency > 1 {
+		if e.compressor, err = newBlockCompressor(e, cfg.Concurrency); err != nil {
+			return nil, err
+		}
+	}
 	return e, nil
 }
 
+// newAppendEncoder returns an encoder that appends to the existing container file in rws.
+// The schema s and any configured codec must match the file.
+func newAppendEncoder(s string, rws io.ReadWriteSeeker, cfg encoderConfig) (*Encoder, error) {
+	if _, err := rws.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	reader := avro.NewReader(rws, 1024)
+	h, err := readHeader(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := avro.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	// The schemas are compared in the form written to the header, which
+	// unlike the canonical form keeps logical types. Values are encoded
+	// with the given schema, so its defaults are used.
+	if schema.String() != h.Schema.String() {
+		return nil, errors.New("schema does not match the existing file schema")
+	}
+	codecName := codecNameOf(h.Meta)
+	if cfg.CodecName != "" && cfg.CodecName != codecName {
+		return nil, fmt.Errorf("codec %s does not match the existing file codec %s", cfg.CodecName, codecName)
+	}
+	if err = checkEncoderCodec(codecName, h.Codec); err != nil {
+		return nil, err
+	}
+
+	if err = skipToEnd(reader, h.Sync); err != nil {
+		return nil, err
+	}
+	if _, err = rws.Seek(0, io.SeekEnd); err != nil {
+		return nil, err
+	}
+
+	writer := avro.NewWriter(rws, 512, avro.WithWriterConfig(cfg.EncodingConfig))
+	buf := &bytes.Buffer{}
+	e := &Encoder{
+		writer:      writer,
+		buf:         buf,
+		encoder:     cfg.EncodingConfig.NewEncoder(schema, buf),
+		schema:      schema,
+		sync:        h.Sync,
+		codec:       h.Codec,
+		codecName:   codecName,
+		codecLvl:    -1,
+		blockLength: cfg.BlockLength,
+	}
+	if cfg.Concurrency > 1 {
+		if e.compressor, err = newBlockCompressor(e, cfg.Concurrency); err != nil {
+			return nil, err
+		}
+	}
+	return e, nil
+}
+
+// fileSize returns the size of the file in rws.
+func fileSize(rws io.ReadWriteSeeker) (int64, error) {
+	// Files are sized with Stat, as files that cannot seek,
+	// such as pipes, are written as new files.
+	if f, ok := rws.(*os.File); ok {
+		info, err := f.Stat()
+		if err != nil {
+			return 0, err
+		}
+		return info.Size(), nil
+	}
+
+	size, err := rws.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, err
+	}
+	if _, err = rws.Seek(0, io.SeekStart); err != nil {
+		return 0, err
+	}
+	return size, nil
+}
+
 // Write v to the internal buffer. This method skips the internal encoder and
 // therefore the caller is responsible for encoding the bytes. No error will be
 // thrown if the bytes does not conform to the schema given to NewEncoder, but
@@ -312,7 +640,7 @@ func (e *Encoder) Write(p []byte) (n int, err error) {
 		}
 	}
 
-	return n, e.writer.Error
+	return n, e.writeError()
 }
 
 // Encode writes the Avro encoding of v to the stream.
@@ -328,11 +656,23 @@ func (e *Encoder) Encode(v any) error {
 		}
 	}
 
-	return e.writer.Error
+	return e.writeError()
 }
 
 // Flush flushes the underlying writer.
+//
+// If the encoder compresses blocks concurrently, Flush waits for all
+// blocks to be written.
 func (e *Encoder) Flush() error {
+	if e.compressor != nil {
+		if e.count > 0 {
+			if err := e.writerBlock(); err != nil {
+				return err
+			}
+		}
+		return e.compressor.wait()
+	}
+
 	if e.count == 0 {
 		return nil
 	}
@@ -346,24 +686,52 @@ func (e *Encoder) Flush() error {
 
 // Close closes the encoder, flushing the writer.
 func (e *Encoder) Close() error {
-	return e.Flush()
+	err := e.Flush()
+	if e.compressor != nil {
+		e.compressor.close()
+		e.compressor = nil
+	}
+	return err
 }
 
 func (e *Encoder) writerBlock() error {
-	e.writer.WriteLong(int64(e.count))
+	if e.compressor != nil {
+		data := make([]byte, e.buf.Len())
+		copy(data, e.buf.Bytes())
+		e.compressor.submit(int64(e.count), data)
+
+		e.count = 0
+		e.buf.Reset()
+		return e.compressor.error()
+	}
 
-	b := e.codec.Encode(e.buf.Bytes())
+	b, err := encode(e.codec, e.buf.Bytes())
+	if err == nil {
+		err = e.writeBlock(int64(e.count), b)
+	}
+
+	e.count = 0
+	e.buf.Reset()
+	return err
+}
 
+func (e *Encoder) writeBlock(count int64, b []byte) error {
+	e.writer.WriteLong(count)
 	e.writer.WriteLong(int64(len(b)))
 	_, _ = e.writer.Write(b)
 
 	_, _ = e.writer.Write(e.sync[:])
 
-	e.count = 0
-	e.buf.Reset()
 	return e.writer.Flush()
 }
 
+func (e *Encoder) writeError() error {
+	if e.compressor != nil {
+		return e.compressor.error()
+	}
+	return e.writer.Error
+}
+
 type ocfHeader struct {
 	Schema avro.Schema
 	Codec  Codec
@@ -378,6 +746,10 @@ func readHeader(reader *avro.Reader) (*ocfHeader, error) {
 		return nil, fmt.Errorf("unexpected error: %w", reader.Error)
 	}
 
+	return newOCFHeader(h)
+}
+
+func newOCFHeader(h Header) (*ocfHeader, error) {
 	if h.Magic != magicBytes {
 		return nil, errors.New("invalid avro file")
 	}
@@ -399,6 +771,14 @@ func readHeader(reader *avro.Reader) (*ocfHeader, error) {
 	}, nil
 }
 
+// codecNameOf returns the codec name in the header metadata.
+func codecNameOf(meta map[string][]byte) CodecName {
+	if name := CodecName(meta[codecKey]); name != "" {
+		return name
+	}
+	return Null
+}
+
 func skipToEnd(reader *avro.Reader, sync [16]byte) error {
 	for {
 		_ = reader.ReadLong()
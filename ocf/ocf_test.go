@@ -5,9 +5,12 @@ import (
 	"compress/flate"
 	"errors"
 	"flag"
+	"fmt"
 	"io"
 	"os"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/kjuulh/avro/v2"
 	"github.com/kjuulh/avro/v2/ocf"
@@ -70,6 +73,12 @@ func TestNewDecoder_InvalidHeader(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestNewDecoder_OversizedMetadata(t *testing.T) {
+	_, err := ocf.NewDecoder(bytes.NewReader(oversizedHeader()))
+
+	assert.ErrorContains(t, err, "size is greater than the maximum")
+}
+
 func TestNewDecoder_InvalidMagic(t *testing.T) {
 	data := []byte{'f', 'o', 'o', 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}
 
@@ -370,6 +379,124 @@ func TestDecoder_WithZStandardHandlesInvalidData(t *testing.T) {
 	assert.Error(t, dec.Error())
 }
 
+func TestDecoder_WithBZip2(t *testing.T) {
+	unionStr := "union value"
+	want := FullRecord{
+		Strings: []string{"string1", "string2", "string3", "string4", "string5"},
+		Longs:   []int64{1, 2, 3, 4, 5},
+		Enum:    "C",
+		Map: map[string]int{
+			"key1": 1,
+			"key2": 2,
+			"key3": 3,
+			"key4": 4,
+			"key5": 5,
+		},
+		Nullable: &unionStr,
+		Fixed:    [16]byte{0x01, 0x02, 0x03, 0x04, 0x01, 0x02, 0x03, 0x04, 0x01, 0x02, 0x03, 0x04, 0x01, 0x02, 0x03, 0x04},
+		Record: &TestRecord{
+			Long:   1925639126735,
+			String: "I am a test record",
+			Int:    666,
+			Float:  7171.17,
+			Double: 916734926348163.01973408746523,
+			Bool:   true,
+		},
+	}
+
+	f, err := os.Open("testdata/full-bzip2.avro")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = f.Close() })
+
+	dec, err := ocf.NewDecoder(f)
+	require.NoError(t, err)
+
+	var count int
+	for dec.HasNext() {
+		count++
+		var got FullRecord
+		err = dec.Decode(&got)
+
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+
+	require.NoError(t, dec.Error())
+	assert.Equal(t, 1, count)
+}
+
+func TestDecoder_WithBZip2HandlesInvalidData(t *testing.T) {
+	f, err := os.Open("testdata/bzip2-invalid-data.avro")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = f.Close() })
+
+	dec, err := ocf.NewDecoder(f)
+	require.NoError(t, err)
+
+	dec.HasNext()
+
+	assert.Error(t, dec.Error())
+}
+
+func TestDecoder_WithXZ(t *testing.T) {
+	unionStr := "union value"
+	want := FullRecord{
+		Strings: []string{"string1", "string2", "string3", "string4", "string5"},
+		Longs:   []int64{1, 2, 3, 4, 5},
+		Enum:    "C",
+		Map: map[string]int{
+			"key1": 1,
+			"key2": 2,
+			"key3": 3,
+			"key4": 4,
+			"key5": 5,
+		},
+		Nullable: &unionStr,
+		Fixed:    [16]byte{0x01, 0x02, 0x03, 0x04, 0x01, 0x02, 0x03, 0x04, 0x01, 0x02, 0x03, 0x04, 0x01, 0x02, 0x03, 0x04},
+		Record: &TestRecord{
+			Long:   1925639126735,
+			String: "I am a test record",
+			Int:    666,
+			Float:  7171.17,
+			Double: 916734926348163.01973408746523,
+			Bool:   true,
+		},
+	}
+
+	f, err := os.Open("testdata/full-xz.avro")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = f.Close() })
+
+	dec, err := ocf.NewDecoder(f)
+	require.NoError(t, err)
+
+	var count int
+	for dec.HasNext() {
+		count++
+		var got FullRecord
+		err = dec.Decode(&got)
+
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+
+	require.NoError(t, dec.Error())
+	assert.Equal(t, 1, count)
+}
+
+func TestDecoder_WithXZHandlesInvalidData(t *testing.T) {
+	f, err := os.Open("testdata/xz-invalid-data.avro")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = f.Close() })
+
+	dec, err := ocf.NewDecoder(f)
+	require.NoError(t, err)
+
+	dec.HasNext()
+
+	assert.Error(t, dec.Error())
+}
+
 func TestDecoder_DecodeAvroError(t *testing.T) {
 	data := []byte{'O', 'b', 'j', 0x01, 0x01, 0x26, 0x16, 'a', 'v', 'r', 'o', '.', 's', 'c', 'h', 'e', 'm', 'a',
 		0x0c, '"', 'l', 'o', 'n', 'g', '"', 0x00, 0xfb, 0x2b, 0x0f, 0x1a, 0xdd, 0xfd, 0x90, 0x7d, 0x87, 0x12,
@@ -416,6 +543,329 @@ func TestDecoder_InvalidBlock(t *testing.T) {
 	assert.Error(t, dec.Error())
 }
 
+func TestDecoder_TruncatedBlock(t *testing.T) {
+	data := seekFile(t, 5)
+	data = data[:len(data)-5]
+
+	dec, err := ocf.NewDecoder(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	for dec.HasNext() {
+		var rec SeekRecord
+		require.NoError(t, dec.Decode(&rec))
+	}
+
+	assert.ErrorIs(t, dec.Error(), io.ErrUnexpectedEOF)
+}
+
+func TestDecoder_WithCorruptBlockHandler(t *testing.T) {
+	tests := []struct {
+		name        string
+		codec       ocf.CodecName
+		corrupt     func(data []byte, blk ocf.BlockInfo) []byte
+		wantIDs     []int64
+		wantErrIs   error
+		wantErrText string
+	}{
+		{
+			name:  "invalid sync marker",
+			codec: ocf.Null,
+			corrupt: func(data []byte, blk ocf.BlockInfo) []byte {
+				data[blk.Offset+2+blk.Size]++
+				return data
+			},
+			wantIDs:     []int64{0, 1, 6, 7, 8, 9},
+			wantErrText: "invalid block",
+		},
+		{
+			name:  "invalid block header",
+			codec: ocf.Null,
+			corrupt: func(data []byte, blk ocf.BlockInfo) []byte {
+				data[blk.Offset] = 0x03
+				return data
+			},
+			wantIDs:     []int64{0, 1, 4, 5, 6, 7, 8, 9},
+			wantErrText: "invalid block",
+		},
+		{
+			name:  "invalid compressed data",
+			codec: ocf.Snappy,
+			corrupt: func(data []byte, blk ocf.BlockInfo) []byte {
+				data[blk.Offset+2]++
+				return data
+			},
+			wantIDs: []int64{0, 1, 4, 5, 6, 7, 8, 9},
+		},
+		{
+			name:  "truncated block",
+			codec: ocf.Null,
+			corrupt: func(data []byte, blk ocf.BlockInfo) []byte {
+				return data[:blk.Offset+3]
+			},
+			wantIDs:   []int64{0, 1},
+			wantErrIs: io.ErrUnexpectedEOF,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		for _, n := range []int{1, 4} {
+			n := n
+			t.Run(fmt.Sprintf("%s concurrency %d", test.name, n), func(t *testing.T) {
+				data := seekFile(t, 10, ocf.WithCodec(test.codec))
+				sdec, err := ocf.NewSeekableDecoder(bytes.NewReader(data))
+				require.NoError(t, err)
+				blocks, err := sdec.Blocks()
+				require.NoError(t, err)
+				data = test.corrupt(data, blocks[1])
+
+				var blkErrs []*ocf.BlockError
+				dec, err := ocf.NewDecoder(bytes.NewReader(data),
+					ocf.WithDecoderConcurrency(n),
+					ocf.WithCorruptBlockHandler(func(err *ocf.BlockError) {
+						blkErrs = append(blkErrs, err)
+					}),
+				)
+				require.NoError(t, err)
+				t.Cleanup(func() { _ = dec.Close() })
+
+				var ids []int64
+				for dec.HasNext() {
+					var rec SeekRecord
+					require.NoError(t, dec.Decode(&rec))
+					ids = append(ids, rec.ID)
+				}
+
+				require.NoError(t, dec.Error())
+				assert.Equal(t, test.wantIDs, ids)
+				require.Len(t, blkErrs, 1)
+				assert.Equal(t, blocks[1].Offset, blkErrs[0].Offset)
+				assert.Error(t, blkErrs[0].Err)
+				if test.wantErrIs != nil {
+					assert.ErrorIs(t, blkErrs[0], test.wantErrIs)
+				}
+				if test.wantErrText != "" {
+					assert.Equal(t, test.wantErrText, blkErrs[0].Err.Error())
+				}
+			})
+		}
+	}
+}
+
+func TestDecoder_WithCorruptBlockHandlerManyBlocks(t *testing.T) {
+	data := seekFile(t, 600)
+	sdec, err := ocf.NewSeekableDecoder(bytes.NewReader(data))
+	require.NoError(t, err)
+	blocks, err := sdec.Blocks()
+	require.NoError(t, err)
+
+	var wantIDs []int64
+	for i, blk := range blocks {
+		switch i % 3 {
+		case 0:
+			wantIDs = append(wantIDs, int64(2*i), int64(2*i+1))
+		case 1:
+			data[blk.Offset+2+blk.Size]++
+		}
+	}
+
+	var blkErrs []*ocf.BlockError
+	dec, err := ocf.NewDecoder(bytes.NewReader(data), ocf.WithCorruptBlockHandler(func(err *ocf.BlockError) {
+		blkErrs = append(blkErrs, err)
+	}))
+	require.NoError(t, err)
+
+	var ids []int64
+	for dec.HasNext() {
+		var rec SeekRecord
+		require.NoError(t, dec.Decode(&rec))
+		ids = append(ids, rec.ID)
+	}
+
+	require.NoError(t, dec.Error())
+	assert.Equal(t, wantIDs, ids)
+	assert.Len(t, blkErrs, len(blocks)/3)
+}
+
+func TestDecoder_WithCorruptBlockHandlerDecodeError(t *testing.T) {
+	buf := &bytes.Buffer{}
+	enc, err := ocf.NewEncoder(`"boolean"`, buf, ocf.WithBlockLength(2))
+	require.NoError(t, err)
+	for i := 0; i < 6; i++ {
+		require.NoError(t, enc.Encode(true))
+	}
+	require.NoError(t, enc.Close())
+	data := buf.Bytes()
+
+	sdec, err := ocf.NewSeekableDecoder(bytes.NewReader(data))
+	require.NoError(t, err)
+	blocks, err := sdec.Blocks()
+	require.NoError(t, err)
+	data[blocks[1].Offset+2] = 0x05
+
+	var blkErrs []*ocf.BlockError
+	dec, err := ocf.NewDecoder(bytes.NewReader(data), ocf.WithCorruptBlockHandler(func(err *ocf.BlockError) {
+		blkErrs = append(blkErrs, err)
+	}))
+	require.NoError(t, err)
+
+	var count, failed int
+	for dec.HasNext() {
+		var b bool
+		if err = dec.Decode(&b); err != nil {
+			var blkErr *ocf.BlockError
+			require.ErrorAs(t, err, &blkErr)
+			failed++
+			continue
+		}
+		assert.True(t, b)
+		count++
+	}
+
+	require.NoError(t, dec.Error())
+	assert.Equal(t, 4, count)
+	assert.Equal(t, 1, failed)
+	require.Len(t, blkErrs, 1)
+	assert.Equal(t, blocks[1].Offset, blkErrs[0].Offset)
+	assert.Equal(t, int64(2), blkErrs[0].Count)
+}
+
+func TestDecoder_WithDecoderConcurrency(t *testing.T) {
+	for _, codec := range []ocf.CodecName{ocf.Null, ocf.Deflate, ocf.Snappy, ocf.ZStandard} {
+		codec := codec
+		t.Run(string(codec), func(t *testing.T) {
+			data := seekFile(t, 101, ocf.WithCodec(codec))
+
+			dec, err := ocf.NewDecoder(bytes.NewReader(data), ocf.WithDecoderConcurrency(4))
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = dec.Close() })
+
+			var got []int64
+			for dec.HasNext() {
+				var rec SeekRecord
+				require.NoError(t, dec.Decode(&rec))
+				got = append(got, rec.ID)
+			}
+
+			require.NoError(t, dec.Error())
+			require.Len(t, got, 101)
+			for i, id := range got {
+				assert.Equal(t, int64(i), id)
+			}
+		})
+	}
+}
+
+func TestDecoder_WithDecoderConcurrencyHandlesInvalidData(t *testing.T) {
+	f, err := os.Open("testdata/deflate-invalid-data.avro")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = f.Close() })
+
+	dec, err := ocf.NewDecoder(f, ocf.WithDecoderConcurrency(2))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = dec.Close() })
+
+	got := dec.HasNext()
+
+	assert.False(t, got)
+	assert.Error(t, dec.Error())
+}
+
+func TestDecoder_WithDecoderConcurrencyInvalidBlock(t *testing.T) {
+	data := seekFile(t, 10)
+	data[len(data)-1]++
+
+	dec, err := ocf.NewDecoder(bytes.NewReader(data), ocf.WithDecoderConcurrency(2))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = dec.Close() })
+
+	var count int
+	for dec.HasNext() {
+		var rec SeekRecord
+		require.NoError(t, dec.Decode(&rec))
+		count++
+	}
+
+	assert.Equal(t, 8, count)
+	assert.Error(t, dec.Error())
+}
+
+func TestDecoder_CloseStopsReadAhead(t *testing.T) {
+	data := seekFile(t, 100)
+
+	dec, err := ocf.NewDecoder(bytes.NewReader(data), ocf.WithDecoderConcurrency(2))
+	require.NoError(t, err)
+	require.True(t, dec.HasNext())
+
+	err = dec.Close()
+	require.NoError(t, err)
+
+	for dec.HasNext() {
+		var rec SeekRecord
+		require.NoError(t, dec.Decode(&rec))
+	}
+	assert.Error(t, dec.Error())
+}
+
+func TestDecoder_WithReaderSchema(t *testing.T) {
+	type WriterRecord struct {
+		A int32  `avro:"a"`
+		B string `avro:"b"`
+		C int64  `avro:"c"`
+	}
+	type ReaderRecord struct {
+		A int64  `avro:"a"`
+		D string `avro:"d"`
+		E int    `avro:"e"`
+	}
+
+	buf := &bytes.Buffer{}
+	enc, err := ocf.NewEncoder(`{"type":"record","name":"test","fields":[
+		{"name":"a","type":"int"},
+		{"name":"b","type":"string"},
+		{"name":"c","type":"long"}
+	]}`, buf)
+	require.NoError(t, err)
+	require.NoError(t, enc.Encode(WriterRecord{A: 27, B: "foo", C: 42}))
+	require.NoError(t, enc.Encode(WriterRecord{A: 28, B: "bar", C: 43}))
+	require.NoError(t, enc.Close())
+
+	readerSchema := avro.MustParse(`{"type":"record","name":"test","fields":[
+		{"name":"a","type":"long"},
+		{"name":"d","aliases":["b"],"type":"string"},
+		{"name":"e","type":"int","default":5}
+	]}`)
+
+	dec, err := ocf.NewDecoder(buf, ocf.WithReaderSchema(readerSchema))
+	require.NoError(t, err)
+
+	var got []ReaderRecord
+	for dec.HasNext() {
+		var rec ReaderRecord
+		require.NoError(t, dec.Decode(&rec))
+		got = append(got, rec)
+	}
+	require.NoError(t, dec.Error())
+
+	want := []ReaderRecord{
+		{A: 27, D: "foo", E: 5},
+		{A: 28, D: "bar", E: 5},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestDecoder_WithReaderSchemaIncompatible(t *testing.T) {
+	buf := &bytes.Buffer{}
+	enc, err := ocf.NewEncoder(`"string"`, buf)
+	require.NoError(t, err)
+	require.NoError(t, enc.Encode("foo"))
+	require.NoError(t, enc.Close())
+
+	_, err = ocf.NewDecoder(buf, ocf.WithReaderSchema(avro.MustParse(`"int"`)))
+
+	assert.Error(t, err)
+}
+
 func TestNewEncoder_InvalidSchema(t *testing.T) {
 	buf := &bytes.Buffer{}
 
@@ -432,6 +882,67 @@ func TestNewEncoder_InvalidCodec(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestNewEncoder_DecodeOnlyCodec(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	_, err := ocf.NewEncoder(`"long"`, buf, ocf.WithCodec(ocf.BZip2))
+
+	assert.Error(t, err)
+}
+
+type decodeOnlyCodec struct {
+	reverseCodec
+}
+
+func (*decodeOnlyCodec) DecodeOnly() {}
+
+func TestNewEncoder_RegisteredDecodeOnlyCodec(t *testing.T) {
+	ocf.RegisterCodec("decode-only", func(int) (ocf.Codec, error) {
+		return &decodeOnlyCodec{}, nil
+	})
+	buf := &bytes.Buffer{}
+
+	_, err := ocf.NewEncoder(`"long"`, buf, ocf.WithCodec("decode-only"))
+
+	assert.EqualError(t, err, "codec decode-only does not support encoding")
+}
+
+func TestBZip2Codec_EncodeErr(t *testing.T) {
+	codec := &ocf.BZip2Codec{}
+
+	_, err := codec.EncodeErr([]byte("foo"))
+
+	assert.Error(t, err)
+}
+
+type errorEncodeCodec struct {
+	reverseCodec
+}
+
+func (*errorEncodeCodec) EncodeErr([]byte) ([]byte, error) {
+	return nil, errors.New("test")
+}
+
+func TestEncoder_EncodeHandlesCodecError(t *testing.T) {
+	ocf.RegisterCodec("error-encode", func(int) (ocf.Codec, error) {
+		return &errorEncodeCodec{}, nil
+	})
+
+	for _, n := range []int{1, 2} {
+		n := n
+		t.Run(fmt.Sprintf("concurrency %d", n), func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			enc, err := ocf.NewEncoder(`"long"`, buf, ocf.WithCodec("error-encode"), ocf.WithEncoderConcurrency(n))
+			require.NoError(t, err)
+			require.NoError(t, enc.Encode(int64(1)))
+
+			err = enc.Close()
+
+			assert.EqualError(t, err, "test")
+		})
+	}
+}
+
 func TestEncoder(t *testing.T) {
 	unionStr := "union value"
 	record := FullRecord{
@@ -579,6 +1090,92 @@ func TestEncoder_ExistingOCF(t *testing.T) {
 	assert.Equal(t, want, got)
 }
 
+func TestEncoder_ExistingReadWriteSeeker(t *testing.T) {
+	file := &memFile{}
+
+	enc, err := ocf.NewEncoder(seekSchema, file, ocf.WithCodec(ocf.Snappy))
+	require.NoError(t, err)
+	require.NoError(t, enc.Encode(SeekRecord{ID: 1}))
+	require.NoError(t, enc.Encode(SeekRecord{ID: 2}))
+	require.NoError(t, enc.Close())
+
+	enc, err = ocf.NewEncoder(seekSchema, file)
+	require.NoError(t, err)
+	require.NoError(t, enc.Encode(SeekRecord{ID: 3}))
+	require.NoError(t, enc.Close())
+
+	dec, err := ocf.NewSeekableDecoder(bytes.NewReader(file.data))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("snappy"), dec.Metadata()["avro.codec"])
+	assert.Equal(t, []int64{1, 2, 3}, readSeekRecords(t, dec))
+}
+
+func TestEncoder_ExistingOCFSchemaMismatch(t *testing.T) {
+	file := &memFile{data: seekFile(t, 2)}
+
+	_, err := ocf.NewEncoder(`{"type":"record","name":"SeekRecord","fields":[{"name":"id","type":"int"}]}`, file)
+
+	assert.Error(t, err)
+}
+
+func TestEncoder_ExistingOCFLogicalTypeMismatch(t *testing.T) {
+	file := &memFile{}
+	enc, err := ocf.NewEncoder(`"long"`, file)
+	require.NoError(t, err)
+	require.NoError(t, enc.Encode(int64(1)))
+	require.NoError(t, enc.Close())
+
+	_, err = ocf.NewEncoder(`{"type":"long","logicalType":"timestamp-millis"}`, file)
+
+	assert.EqualError(t, err, "schema does not match the existing file schema")
+}
+
+func TestEncoder_ExistingOCFUsesSchemaDefaults(t *testing.T) {
+	file := &memFile{}
+	enc, err := ocf.NewEncoder(`{"type":"record","name":"test","fields":[{"name":"a","type":"long"},{"name":"b","type":"string"}]}`, file)
+	require.NoError(t, err)
+	require.NoError(t, enc.Encode(map[string]any{"a": int64(1), "b": "foo"}))
+	require.NoError(t, enc.Close())
+
+	type record struct {
+		A int64 `avro:"a"`
+	}
+	enc, err = ocf.NewEncoder(`{"type":"record","name":"test","fields":[{"name":"a","type":"long"},{"name":"b","type":"string","default":"bar"}]}`, file)
+	require.NoError(t, err)
+	require.NoError(t, enc.Encode(record{A: 2}))
+	require.NoError(t, enc.Close())
+
+	dec, err := ocf.NewDecoder(bytes.NewReader(file.data))
+	require.NoError(t, err)
+	var got []map[string]any
+	for dec.HasNext() {
+		var rec map[string]any
+		require.NoError(t, dec.Decode(&rec))
+		got = append(got, rec)
+	}
+	require.NoError(t, dec.Error())
+	want := []map[string]any{{"a": int64(1), "b": "foo"}, {"a": int64(2), "b": "bar"}}
+	assert.Equal(t, want, got)
+}
+
+func TestEncoder_ExistingOCFCodecMismatch(t *testing.T) {
+	file := &memFile{data: seekFile(t, 2)}
+
+	_, err := ocf.NewEncoder(seekSchema, file, ocf.WithCodec(ocf.Deflate))
+
+	assert.Error(t, err)
+}
+
+func TestEncoder_ExistingOCFInvalidBlock(t *testing.T) {
+	data := seekFile(t, 2)
+	data[len(data)-1]++
+	file := &memFile{data: data}
+
+	_, err := ocf.NewEncoder(seekSchema, file)
+
+	assert.Error(t, err)
+}
+
 func TestEncoder_NilWriter(t *testing.T) {
 	_, err := ocf.NewEncoder(schema, nil)
 
@@ -664,6 +1261,22 @@ func TestEncoder_EncodeCompressesDeflate(t *testing.T) {
 	assert.Equal(t, 926, buf.Len())
 }
 
+func TestEncoder_WithCodecLevel(t *testing.T) {
+	buf := &bytes.Buffer{}
+	enc, err := ocf.NewEncoder(`"long"`, buf, ocf.WithCodec(ocf.ZStandard), ocf.WithCodecLevel(19))
+	require.NoError(t, err)
+	require.NoError(t, enc.Encode(int64(27)))
+	require.NoError(t, enc.Close())
+
+	dec, err := ocf.NewDecoder(buf)
+	require.NoError(t, err)
+	assert.Equal(t, []byte(ocf.ZStandard), dec.Metadata()["avro.codec"])
+	require.True(t, dec.HasNext())
+	var got int64
+	require.NoError(t, dec.Decode(&got))
+	assert.Equal(t, int64(27), got)
+}
+
 func TestEncoder_EncodeCompressesDeflateWithLevel(t *testing.T) {
 	unionStr := "union value"
 	record := FullRecord{
@@ -777,6 +1390,28 @@ func TestEncoder_EncodeCompressesZStandard(t *testing.T) {
 	assert.Equal(t, 951, buf.Len())
 }
 
+func TestEncoder_EncodeCompressesXZ(t *testing.T) {
+	buf := &bytes.Buffer{}
+	enc, err := ocf.NewEncoder(`"long"`, buf, ocf.WithCodec(ocf.XZ))
+	require.NoError(t, err)
+
+	err = enc.Encode(int64(27))
+	require.NoError(t, err)
+	err = enc.Close()
+	require.NoError(t, err)
+
+	dec, err := ocf.NewDecoder(buf)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("xz"), dec.Metadata()["avro.codec"])
+
+	require.True(t, dec.HasNext())
+	var got int64
+	require.NoError(t, dec.Decode(&got))
+	assert.Equal(t, int64(27), got)
+	assert.False(t, dec.HasNext())
+	require.NoError(t, dec.Error())
+}
+
 func TestEncoder_EncodeError(t *testing.T) {
 	buf := &bytes.Buffer{}
 	enc, err := ocf.NewEncoder(`"long"`, buf)
@@ -819,6 +1454,195 @@ func TestEncoder_CloseHandlesWriteBlockError(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestEncoder_WithEncoderConcurrency(t *testing.T) {
+	for _, codec := range []ocf.CodecName{ocf.Null, ocf.Deflate, ocf.Snappy, ocf.ZStandard} {
+		codec := codec
+		t.Run(string(codec), func(t *testing.T) {
+			sync := [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+			want := seekFile(t, 101, ocf.WithCodec(codec), ocf.WithSyncBlock(sync))
+
+			got := seekFile(t, 101, ocf.WithCodec(codec), ocf.WithSyncBlock(sync), ocf.WithEncoderConcurrency(4))
+
+			// The header metadata is not ordered, compare the blocks.
+			wantBlocks := want[bytes.Index(want, sync[:]):]
+			gotBlocks := got[bytes.Index(got, sync[:]):]
+			assert.Equal(t, wantBlocks, gotBlocks)
+		})
+	}
+}
+
+func TestEncoder_WithEncoderConcurrencyFlushWaits(t *testing.T) {
+	buf := &bytes.Buffer{}
+	enc, err := ocf.NewEncoder(seekSchema, buf, ocf.WithBlockLength(2), ocf.WithEncoderConcurrency(4))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = enc.Close() })
+
+	for i := 0; i < 11; i++ {
+		require.NoError(t, enc.Encode(SeekRecord{ID: int64(i)}))
+	}
+	err = enc.Flush()
+	require.NoError(t, err)
+
+	dec, err := ocf.NewDecoder(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	var count int
+	for dec.HasNext() {
+		var rec SeekRecord
+		require.NoError(t, dec.Decode(&rec))
+		assert.Equal(t, int64(count), rec.ID)
+		count++
+	}
+	require.NoError(t, dec.Error())
+	assert.Equal(t, 11, count)
+}
+
+func TestEncoder_WithEncoderConcurrencyCloseHandlesWriteBlockError(t *testing.T) {
+	w := &errorBlockWriter{}
+	enc, err := ocf.NewEncoder(`"long"`, w, ocf.WithBlockLength(1), ocf.WithEncoderConcurrency(2))
+	require.NoError(t, err)
+	_ = enc.Encode(int64(1))
+
+	err = enc.Close()
+
+	assert.Error(t, err)
+}
+
+type reverseCodec struct {
+	lvl int
+}
+
+func (c *reverseCodec) Decode(b []byte) ([]byte, error) {
+	return c.reverse(b), nil
+}
+
+func (c *reverseCodec) Encode(b []byte) []byte {
+	return c.reverse(b)
+}
+
+func (c *reverseCodec) reverse(b []byte) []byte {
+	out := make([]byte, len(b))
+	for i := range b {
+		out[len(b)-1-i] = b[i]
+	}
+	return out
+}
+
+func TestRegisterCodec(t *testing.T) {
+	var lvls []int
+	ocf.RegisterCodec("reverse", func(lvl int) (ocf.Codec, error) {
+		lvls = append(lvls, lvl)
+		return &reverseCodec{lvl: lvl}, nil
+	})
+
+	buf := &bytes.Buffer{}
+	enc, err := ocf.NewEncoder(`"string"`, buf, ocf.WithCodec("reverse"))
+	require.NoError(t, err)
+	require.NoError(t, enc.Encode("foobar"))
+	require.NoError(t, enc.Close())
+
+	assert.Contains(t, buf.String(), "raboof")
+
+	dec, err := ocf.NewDecoder(buf)
+	require.NoError(t, err)
+	require.True(t, dec.HasNext())
+	var got string
+	require.NoError(t, dec.Decode(&got))
+	assert.Equal(t, "foobar", got)
+	assert.Equal(t, []int{-1, -1}, lvls)
+}
+
+func TestRegisterCodec_FactoryError(t *testing.T) {
+	ocf.RegisterCodec("broken", func(int) (ocf.Codec, error) {
+		return nil, errors.New("test")
+	})
+
+	_, err := ocf.NewEncoder(`"string"`, &bytes.Buffer{}, ocf.WithCodec("broken"))
+
+	assert.Error(t, err)
+}
+
+// exclusiveCodec is a codec that records when it is used concurrently.
+type exclusiveCodec struct {
+	reverseCodec
+
+	busy       atomic.Bool
+	concurrent *atomic.Bool
+}
+
+func (c *exclusiveCodec) Decode(b []byte) ([]byte, error) {
+	defer c.use()()
+	return c.reverseCodec.Decode(b)
+}
+
+func (c *exclusiveCodec) Encode(b []byte) []byte {
+	defer c.use()()
+	return c.reverseCodec.Encode(b)
+}
+
+func (c *exclusiveCodec) use() func() {
+	if !c.busy.CompareAndSwap(false, true) {
+		c.concurrent.Store(true)
+	}
+	time.Sleep(time.Millisecond)
+	return func() { c.busy.Store(false) }
+}
+
+func TestEncoder_WithEncoderConcurrencyUsesCodecPerWorker(t *testing.T) {
+	var (
+		concurrent atomic.Bool
+		codecs     int
+	)
+	ocf.RegisterCodec("exclusive-encode", func(int) (ocf.Codec, error) {
+		codecs++
+		return &exclusiveCodec{concurrent: &concurrent}, nil
+	})
+
+	buf := &bytes.Buffer{}
+	enc, err := ocf.NewEncoder(`"long"`, buf, ocf.WithCodec("exclusive-encode"), ocf.WithBlockLength(1), ocf.WithEncoderConcurrency(4))
+	require.NoError(t, err)
+	for i := 0; i < 20; i++ {
+		require.NoError(t, enc.Encode(int64(i)))
+	}
+	require.NoError(t, enc.Close())
+
+	assert.Equal(t, 5, codecs)
+	assert.False(t, concurrent.Load())
+}
+
+func TestDecoder_WithDecoderConcurrencyUsesCodecPerWorker(t *testing.T) {
+	var (
+		concurrent atomic.Bool
+		codecs     int
+	)
+	ocf.RegisterCodec("exclusive-decode", func(int) (ocf.Codec, error) {
+		codecs++
+		return &exclusiveCodec{concurrent: &concurrent}, nil
+	})
+	buf := &bytes.Buffer{}
+	enc, err := ocf.NewEncoder(`"long"`, buf, ocf.WithCodec("exclusive-decode"), ocf.WithBlockLength(1))
+	require.NoError(t, err)
+	for i := 0; i < 20; i++ {
+		require.NoError(t, enc.Encode(int64(i)))
+	}
+	require.NoError(t, enc.Close())
+	codecs = 0
+
+	dec, err := ocf.NewDecoder(buf, ocf.WithDecoderConcurrency(4))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = dec.Close() })
+	var count int
+	for dec.HasNext() {
+		var got int64
+		require.NoError(t, dec.Decode(&got))
+		count++
+	}
+	require.NoError(t, dec.Error())
+
+	assert.Equal(t, 20, count)
+	assert.Equal(t, 5, codecs)
+	assert.False(t, concurrent.Load())
+}
+
 func TestEncodeDecodeMetadata(t *testing.T) {
 	buf := &bytes.Buffer{}
 	enc, _ := ocf.NewEncoder(`"long"`, buf, ocf.WithMetadata(map[string][]byte{
@@ -885,6 +1709,44 @@ func copyToTemp(t *testing.T, src string) *os.File {
 	return file
 }
 
+// memFile is an in memory io.ReadWriteSeeker.
+type memFile struct {
+	data []byte
+	pos  int64
+}
+
+func (f *memFile) Read(p []byte) (int, error) {
+	if f.pos >= int64(len(f.data)) {
+		return 0, io.EOF
+	}
+	n := copy(p, f.data[f.pos:])
+	f.pos += int64(n)
+	return n, nil
+}
+
+func (f *memFile) Write(p []byte) (int, error) {
+	if end := f.pos + int64(len(p)); end > int64(len(f.data)) {
+		f.data = append(f.data, make([]byte, end-int64(len(f.data)))...)
+	}
+	n := copy(f.data[f.pos:], p)
+	f.pos += int64(n)
+	return n, nil
+}
+
+func (f *memFile) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += f.pos
+	case io.SeekEnd:
+		offset += int64(len(f.data))
+	}
+	if offset < 0 {
+		return 0, errors.New("negative position")
+	}
+	f.pos = offset
+	return offset, nil
+}
+
 type errorBlockWriter struct {
 	headerWritten bool
 }
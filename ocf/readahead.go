@@ -0,0 +1,139 @@
+package ocf
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+var errDecoderClosed = errors.New("decoder: closed")
+
+// decompressedBlock is the result of decompressing a block.
+type decompressedBlock struct {
+	blk rawBlock
+	err error
+}
+
+type decompressJob struct {
+	blk rawBlock
+	res chan<- decompressedBlock
+}
+
+// blockReadAhead reads blocks ahead of the decoder, decompressing them on a
+// pool of workers. The results are delivered in block order, with at most
+// n blocks in flight.
+type blockReadAhead struct {
+	results chan chan decompressedBlock
+	pending chan decompressedBlock
+	done    chan struct{}
+	once    sync.Once
+
+	err error
+}
+
+// newBlockReadAhead returns a read ahead for the decoder. Each worker
+// decompresses with its own codec, as codecs need not be safe for concurrent use.
+func newBlockReadAhead(d *Decoder, n int) (*blockReadAhead, error) {
+	workers := make([]Codec, n)
+	for i := range workers {
+		codec, err := resolveCodec(codecNameOf(d.meta), -1)
+		if err != nil {
+			return nil, err
+		}
+		workers[i] = codec
+	}
+
+	ra := &blockReadAhead{
+		results: make(chan chan decompressedBlock, n),
+		done:    make(chan struct{}),
+	}
+
+	jobs := make(chan decompressJob, n)
+	for _, codec := range workers {
+		codec := codec
+		go func() {
+			for job := range jobs {
+				blk := job.blk
+				blk.data, blk.err = codec.Decode(blk.data)
+				job.res <- decompressedBlock{blk: blk}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(ra.results)
+		defer close(jobs)
+
+		for {
+			blk, err := d.readRawBlock()
+
+			res := make(chan decompressedBlock, 1)
+			select {
+			case ra.results <- res:
+			case <-ra.done:
+				return
+			}
+
+			if err != nil {
+				res <- decompressedBlock{err: err}
+				return
+			}
+			if blk.err != nil || blk.count <= 0 {
+				res <- decompressedBlock{blk: blk}
+				continue
+			}
+
+			select {
+			case jobs <- decompressJob{blk: blk, res: res}:
+			case <-ra.done:
+				return
+			}
+		}
+	}()
+
+	return ra, nil
+}
+
+// next waits for the next block, loading it into the decoder and returning its record count.
+// When ctx is done while waiting, 0 is returned and the block is waited for by the next call.
+func (ra *blockReadAhead) next(ctx context.Context, d *Decoder) int64 {
+	if ra.err != nil {
+		return 0
+	}
+
+	if ra.pending == nil {
+		select {
+		case ra.pending = <-ra.results:
+		case <-ra.done:
+			ra.err = errDecoderClosed
+			return 0
+		case <-ctx.Done():
+			return 0
+		}
+	}
+
+	var res decompressedBlock
+	select {
+	case res = <-ra.pending:
+		ra.pending = nil
+	case <-ra.done:
+		ra.err = errDecoderClosed
+		return 0
+	case <-ctx.Done():
+		return 0
+	}
+	if res.err != nil {
+		ra.err = res.err
+		return 0
+	}
+
+	count, err := d.loadBlock(res.blk)
+	if err != nil {
+		ra.err = err
+	}
+	return count
+}
+
+func (ra *blockReadAhead) close() {
+	ra.once.Do(func() { close(ra.done) })
+}
@@ -0,0 +1,148 @@
+package ocf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Position is the position of a value in a container file.
+type Position struct {
+	// Block is the byte offset of the block holding the value.
+	Block int64
+	// Index is the index of the value in its block.
+	Index int64
+}
+
+// Reader reads values of type T from a container file.
+type Reader[T any] struct {
+	dec *Decoder
+
+	val T
+	pos Position
+	err error
+}
+
+// NewReader returns a new reader that reads values of type T from r.
+func NewReader[T any](r io.Reader, opts ...DecoderFunc) (*Reader[T], error) {
+	dec, err := NewDecoder(r, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Reader[T]{dec: dec}, nil
+}
+
+// ResumeReader returns a new reader that reads values of type T from r,
+// starting at the value at pos.
+//
+// To resume reading after a value, use its position with Index incremented.
+func ResumeReader[T any](r io.ReadSeeker, pos Position, opts ...DecoderFunc) (*Reader[T], error) {
+	if pos.Block < 0 || pos.Index < 0 {
+		return nil, fmt.Errorf("decoder: invalid position %+v", pos)
+	}
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("decoder: %w", err)
+	}
+
+	dec, err := newDecoder(r, r, pos.Block, opts)
+	if err != nil {
+		return nil, err
+	}
+	rdr := &Reader[T]{dec: dec}
+
+	for i := int64(0); i < pos.Index; i++ {
+		if !dec.HasNext() {
+			_ = dec.Close()
+			if err = dec.Error(); err != nil {
+				return nil, err
+			}
+			return nil, fmt.Errorf("decoder: position %+v out of range", pos)
+		}
+
+		var v T
+		if err = dec.Decode(&v); err != nil {
+			_ = dec.Close()
+			return nil, err
+		}
+	}
+	return rdr, nil
+}
+
+// Metadata returns the header metadata.
+func (r *Reader[T]) Metadata() map[string][]byte {
+	return r.dec.Metadata()
+}
+
+// Next reads the next value, returning false when there are no more values
+// or an error occurred. Cancellation of ctx is checked before each block is read.
+//
+// In recovery mode, values that cannot be decoded are skipped.
+func (r *Reader[T]) Next(ctx context.Context) bool {
+	if r.err != nil {
+		return false
+	}
+
+	var v T
+	for {
+		if r.dec.count <= 0 {
+			if err := ctx.Err(); err != nil {
+				r.err = err
+				return false
+			}
+		}
+		if !r.dec.hasNext(ctx) {
+			if err := ctx.Err(); err != nil {
+				r.err = err
+				return false
+			}
+			r.err = r.dec.Error()
+			if r.err == nil {
+				r.err = io.EOF
+			}
+			return false
+		}
+
+		err := r.dec.Decode(&v)
+		if err == nil {
+			break
+		}
+
+		// Corrupt blocks are reported to the handler in recovery mode.
+		var blkErr *BlockError
+		if r.dec.onBlockError == nil || !errors.As(err, &blkErr) {
+			r.err = err
+			return false
+		}
+		v = *new(T)
+	}
+
+	r.val = v
+	r.pos = Position{Block: r.dec.block.offset, Index: r.dec.block.count - r.dec.count - 1}
+	return true
+}
+
+// Value returns the value read by Next.
+func (r *Reader[T]) Value() T {
+	return r.val
+}
+
+// Position returns the position of the value read by Next.
+func (r *Reader[T]) Position() Position {
+	return r.pos
+}
+
+// Error returns the error that stopped reading, if any.
+func (r *Reader[T]) Error() error {
+	if errors.Is(r.err, io.EOF) {
+		return nil
+	}
+	return r.err
+}
+
+// Close stops the read ahead of a concurrent reader.
+// It does not close the underlying reader.
+func (r *Reader[T]) Close() error {
+	return r.dec.Close()
+}
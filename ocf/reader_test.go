@@ -0,0 +1,234 @@
+package ocf_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/kjuulh/avro/v2/ocf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewReader_InvalidHeader(t *testing.T) {
+	_, err := ocf.NewReader[SeekRecord](bytes.NewReader([]byte{'O', 'b', 'j', 1, 0x02}))
+
+	assert.Error(t, err)
+}
+
+func TestReader(t *testing.T) {
+	data := seekFile(t, 5)
+	blocks := fileBlocks(t, data)
+
+	for _, n := range []int{1, 3} {
+		r, err := ocf.NewReader[SeekRecord](bytes.NewReader(data), ocf.WithDecoderConcurrency(n))
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = r.Close() })
+
+		var (
+			ids []int64
+			pos []ocf.Position
+		)
+		for r.Next(context.Background()) {
+			ids = append(ids, r.Value().ID)
+			pos = append(pos, r.Position())
+		}
+
+		require.NoError(t, r.Error())
+		assert.Equal(t, []byte("null"), r.Metadata()["avro.codec"])
+		assert.Equal(t, []int64{0, 1, 2, 3, 4}, ids)
+		want := []ocf.Position{
+			{Block: blocks[0].Offset, Index: 0},
+			{Block: blocks[0].Offset, Index: 1},
+			{Block: blocks[1].Offset, Index: 0},
+			{Block: blocks[1].Offset, Index: 1},
+			{Block: blocks[2].Offset, Index: 0},
+		}
+		assert.Equal(t, want, pos)
+	}
+}
+
+func TestReader_ContextCanceled(t *testing.T) {
+	data := seekFile(t, 5)
+
+	r, err := ocf.NewReader[SeekRecord](bytes.NewReader(data))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var ids []int64
+	for r.Next(ctx) {
+		ids = append(ids, r.Value().ID)
+		cancel()
+	}
+
+	assert.Equal(t, []int64{0, 1}, ids)
+	assert.ErrorIs(t, r.Error(), context.Canceled)
+	assert.False(t, r.Next(context.Background()))
+}
+
+func TestReader_ContextCanceledWhileReadingAhead(t *testing.T) {
+	data := seekFile(t, 5)
+	blocks := fileBlocks(t, data)
+	pr, pw := io.Pipe()
+	t.Cleanup(func() { _ = pw.Close() })
+	go func() {
+		// Write the header only, blocking the read ahead.
+		_, _ = pw.Write(data[:blocks[0].Offset])
+	}()
+
+	r, err := ocf.NewReader[SeekRecord](pr, ocf.WithDecoderConcurrency(2))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = r.Close() })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	assert.False(t, r.Next(ctx))
+	assert.ErrorIs(t, r.Error(), context.DeadlineExceeded)
+}
+
+func TestReader_DecodeError(t *testing.T) {
+	data := seekFile(t, 5)
+
+	r, err := ocf.NewReader[string](bytes.NewReader(data))
+	require.NoError(t, err)
+
+	assert.False(t, r.Next(context.Background()))
+	assert.Error(t, r.Error())
+}
+
+func TestReader_WithCorruptBlockHandler(t *testing.T) {
+	buf := &bytes.Buffer{}
+	enc, err := ocf.NewEncoder(`"boolean"`, buf, ocf.WithBlockLength(2))
+	require.NoError(t, err)
+	for i := 0; i < 6; i++ {
+		require.NoError(t, enc.Encode(true))
+	}
+	require.NoError(t, enc.Close())
+	data := buf.Bytes()
+	blocks := fileBlocks(t, data)
+	data[blocks[1].Offset+2] = 0x05
+
+	var blkErrs int
+	r, err := ocf.NewReader[bool](bytes.NewReader(data), ocf.WithCorruptBlockHandler(func(*ocf.BlockError) {
+		blkErrs++
+	}))
+	require.NoError(t, err)
+
+	var count int
+	for r.Next(context.Background()) {
+		assert.True(t, r.Value())
+		count++
+	}
+
+	require.NoError(t, r.Error())
+	assert.Equal(t, 4, count)
+	assert.Equal(t, 1, blkErrs)
+}
+
+func TestReader_WithCorruptBlockHandlerDecodeError(t *testing.T) {
+	data := seekFile(t, 5)
+
+	var blkErrs int
+	r, err := ocf.NewReader[string](bytes.NewReader(data), ocf.WithCorruptBlockHandler(func(*ocf.BlockError) {
+		blkErrs++
+	}))
+	require.NoError(t, err)
+
+	assert.False(t, r.Next(context.Background()))
+	var blkErr *ocf.BlockError
+	assert.Error(t, r.Error())
+	assert.False(t, errors.As(r.Error(), &blkErr))
+	assert.Equal(t, 0, blkErrs)
+}
+
+func TestResumeReader(t *testing.T) {
+	data := seekFile(t, 5, ocf.WithCodec(ocf.Deflate))
+
+	r, err := ocf.NewReader[SeekRecord](bytes.NewReader(data))
+	require.NoError(t, err)
+	var pos []ocf.Position
+	for r.Next(context.Background()) {
+		pos = append(pos, r.Position())
+	}
+	require.NoError(t, r.Error())
+
+	for i, p := range pos {
+		r, err = ocf.ResumeReader[SeekRecord](bytes.NewReader(data), p)
+		require.NoError(t, err)
+
+		var ids []int64
+		for r.Next(context.Background()) {
+			ids = append(ids, r.Value().ID)
+		}
+		require.NoError(t, r.Error())
+
+		var want []int64
+		for id := int64(i); id < 5; id++ {
+			want = append(want, id)
+		}
+		assert.Equal(t, want, ids)
+	}
+}
+
+func TestResumeReader_AfterLastValueInBlock(t *testing.T) {
+	data := seekFile(t, 5)
+	blocks := fileBlocks(t, data)
+
+	r, err := ocf.ResumeReader[SeekRecord](bytes.NewReader(data), ocf.Position{Block: blocks[0].Offset, Index: 2})
+	require.NoError(t, err)
+
+	var ids []int64
+	for r.Next(context.Background()) {
+		ids = append(ids, r.Value().ID)
+	}
+	require.NoError(t, r.Error())
+	assert.Equal(t, []int64{2, 3, 4}, ids)
+}
+
+func TestResumeReader_InvalidPosition(t *testing.T) {
+	data := seekFile(t, 5)
+	blocks := fileBlocks(t, data)
+
+	tests := []struct {
+		name string
+		pos  ocf.Position
+	}{
+		{
+			name: "negative index",
+			pos:  ocf.Position{Block: blocks[0].Offset, Index: -1},
+		},
+		{
+			name: "index out of range",
+			pos:  ocf.Position{Block: blocks[2].Offset, Index: 2},
+		},
+		{
+			name: "offset not at a block",
+			pos:  ocf.Position{Block: blocks[0].Offset + 1, Index: 1},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ocf.ResumeReader[SeekRecord](bytes.NewReader(data), test.pos)
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+func fileBlocks(t *testing.T, data []byte) []ocf.BlockInfo {
+	t.Helper()
+
+	dec, err := ocf.NewSeekableDecoder(bytes.NewReader(data))
+	require.NoError(t, err)
+	blocks, err := dec.Blocks()
+	require.NoError(t, err)
+	return blocks
+}
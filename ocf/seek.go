@@ -0,0 +1,520 @@
+package ocf
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/kjuulh/avro/v2"
+)
+
+const syncSize = 16
+
+// maxByteSliceSize is the maximum size of a header metadata key or value,
+// matching the default avro.Config MaxByteSliceSize.
+const maxByteSliceSize = 1_048_576 // 1 MiB
+
+// BlockInfo describes a data block in a container file.
+type BlockInfo struct {
+	// Offset is the byte offset of the start of the block.
+	Offset int64
+	// Count is the number of records in the block.
+	Count int64
+	// Size is the size of the compressed block data in bytes.
+	Size int64
+	// Record is the index of the first record of the block in the file.
+	Record int64
+}
+
+// SeekableDecoder reads and decodes Avro values from a container file,
+// allowing random access to its blocks.
+type SeekableDecoder struct {
+	r      *seekReader
+	reader *avro.Reader
+	schema avro.Schema
+	meta   map[string][]byte
+	sync   [16]byte
+
+	codec Codec
+
+	dataOffset int64
+	index      []BlockInfo
+	indexed    bool
+
+	block BlockInfo
+	count int64
+	err   error
+}
+
+// NewSeekableDecoder returns a new seekable decoder that reads from r.
+func NewSeekableDecoder(r io.ReadSeeker) (*SeekableDecoder, error) {
+	sr := &seekReader{rs: r}
+	if err := sr.seek(0); err != nil {
+		return nil, fmt.Errorf("decoder: %w", err)
+	}
+
+	h, err := sr.readHeader()
+	if err != nil {
+		return nil, fmt.Errorf("decoder: %w", err)
+	}
+
+	return &SeekableDecoder{
+		r:          sr,
+		reader:     avro.NewReader(nil, 0),
+		schema:     h.Schema,
+		meta:       h.Meta,
+		sync:       h.Sync,
+		codec:      h.Codec,
+		dataOffset: sr.pos,
+		block:      BlockInfo{Offset: sr.pos},
+	}, nil
+}
+
+// NewSeekableDecoderAt returns a new seekable decoder that reads
+// the container file of the given size from r.
+func NewSeekableDecoderAt(r io.ReaderAt, size int64) (*SeekableDecoder, error) {
+	return NewSeekableDecoder(io.NewSectionReader(r, 0, size))
+}
+
+// Metadata returns the header metadata.
+func (d *SeekableDecoder) Metadata() map[string][]byte {
+	return d.meta
+}
+
+// Blocks returns the index of the data blocks in the file.
+//
+// The index is built on first use by scanning the block headers and
+// sync markers of the whole file. The returned slice must not be modified.
+func (d *SeekableDecoder) Blocks() ([]BlockInfo, error) {
+	if d.indexed {
+		return d.index, nil
+	}
+
+	pos := d.r.pos
+	defer func() { _ = d.r.seek(pos) }()
+
+	if err := d.r.seek(d.dataOffset); err != nil {
+		return nil, fmt.Errorf("decoder: %w", err)
+	}
+
+	var (
+		index  []BlockInfo
+		record int64
+	)
+	for {
+		offset := d.r.pos
+		count, size, err := d.r.readBlockHeader()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("decoder: block at offset %d: %w", offset, err)
+		}
+		if err = d.r.discard(size); err != nil {
+			return nil, fmt.Errorf("decoder: block at offset %d: %w", offset, err)
+		}
+		if err = d.r.readSync(d.sync); err != nil {
+			return nil, fmt.Errorf("decoder: block at offset %d: %w", offset, err)
+		}
+
+		index = append(index, BlockInfo{Offset: offset, Count: count, Size: size, Record: record})
+		record += count
+	}
+
+	d.index = index
+	d.indexed = true
+	return index, nil
+}
+
+// SeekToBlock positions the decoder at the start of the nth data block.
+func (d *SeekableDecoder) SeekToBlock(n int) error {
+	index, err := d.Blocks()
+	if err != nil {
+		return err
+	}
+	if n < 0 || n >= len(index) {
+		return fmt.Errorf("decoder: block %d out of range", n)
+	}
+
+	return d.seek(index[n].Offset)
+}
+
+// SeekToRecord positions the decoder at the nth record in the file.
+func (d *SeekableDecoder) SeekToRecord(n int64) error {
+	index, err := d.Blocks()
+	if err != nil {
+		return err
+	}
+
+	i := sort.Search(len(index), func(i int) bool {
+		return index[i].Record+index[i].Count > n
+	})
+	if n < 0 || i == len(index) {
+		return fmt.Errorf("decoder: record %d out of range", n)
+	}
+	if err = d.SeekToBlock(i); err != nil {
+		return err
+	}
+
+	if !d.HasNext() {
+		return d.Error()
+	}
+
+	// Skip the preceding records of the block without decoding them.
+	for skip := n - index[i].Record; skip > 0; skip-- {
+		d.count--
+		d.reader.SkipVal(d.schema)
+	}
+	if err = d.reader.Error; err != nil {
+		d.count = 0
+		d.err = unexpectedEOF(err)
+		return fmt.Errorf("decoder: %w", d.err)
+	}
+	return nil
+}
+
+// Sync positions the decoder at the first block following a sync marker
+// that starts at or after offset. If there is no such block, HasNext
+// returns false.
+//
+// Together with PastSync, this allows the file to be read in splits:
+// a split from start to end reads the blocks following Sync(start)
+// until PastSync(end).
+func (d *SeekableDecoder) Sync(offset int64) error {
+	if offset <= d.dataOffset-syncSize {
+		return d.seek(d.dataOffset)
+	}
+
+	if err := d.seek(offset); err != nil {
+		return err
+	}
+
+	if err := d.r.skipSync(d.sync, nil); err != nil {
+		if errors.Is(err, io.EOF) {
+			d.err = io.EOF
+			return nil
+		}
+		d.err = err
+		return fmt.Errorf("decoder: %w", err)
+	}
+	d.block = BlockInfo{Offset: d.r.pos}
+	return nil
+}
+
+// PastSync determines if the current block follows a sync marker
+// that starts at or after offset.
+func (d *SeekableDecoder) PastSync(offset int64) bool {
+	return d.block.Offset >= offset+syncSize
+}
+
+// Block returns the block the next value is read from.
+//
+// Block is only valid after HasNext returns true.
+func (d *SeekableDecoder) Block() BlockInfo {
+	return d.block
+}
+
+// HasNext determines if there is another value to read.
+func (d *SeekableDecoder) HasNext() bool {
+	for d.count <= 0 {
+		if d.err != nil {
+			return false
+		}
+		d.readBlock()
+	}
+	return true
+}
+
+// Decode reads the next Avro encoded value from its input and stores it in the value pointed to by v.
+func (d *SeekableDecoder) Decode(v any) error {
+	if d.count <= 0 {
+		return errors.New("decoder: no data found, call HasNext first")
+	}
+
+	d.count--
+
+	d.reader.ReadVal(d.schema, v)
+	return unexpectedEOF(d.reader.Error)
+}
+
+// Error returns the last reader error.
+func (d *SeekableDecoder) Error() error {
+	if errors.Is(d.err, io.EOF) {
+		return nil
+	}
+
+	return d.err
+}
+
+func (d *SeekableDecoder) seek(offset int64) error {
+	d.count = 0
+	d.err = nil
+	d.block = BlockInfo{Offset: offset}
+	if err := d.r.seek(offset); err != nil {
+		d.err = err
+		return fmt.Errorf("decoder: %w", err)
+	}
+	return nil
+}
+
+func (d *SeekableDecoder) readBlock() {
+	offset := d.r.pos
+	count, size, err := d.r.readBlockHeader()
+	if err != nil {
+		d.err = err
+		return
+	}
+
+	data, err := d.r.readN(size)
+	if err != nil {
+		d.err = unexpectedEOF(err)
+		return
+	}
+	if err = d.r.readSync(d.sync); err != nil {
+		d.err = fmt.Errorf("decoder: %w", err)
+		return
+	}
+
+	data, err = d.codec.Decode(data)
+	if err != nil {
+		d.err = err
+		return
+	}
+	d.reader.Reset(data)
+	d.reader.Error = nil
+
+	d.block = BlockInfo{Offset: offset, Count: count, Size: size}
+	if d.indexed {
+		i := sort.Search(len(d.index), func(i int) bool { return d.index[i].Offset >= offset })
+		if i < len(d.index) && d.index[i].Offset == offset {
+			d.block = d.index[i]
+		}
+	}
+	d.count = count
+}
+
+// seekReader tracks the offset of a buffered reader.
+// It can only seek when reading from an io.ReadSeeker.
+type seekReader struct {
+	rs  io.ReadSeeker
+	br  *bufio.Reader
+	pos int64
+
+	// unreadBuf holds bytes put back by unread, which are read before br.
+	unreadBuf []byte
+}
+
+func (r *seekReader) seek(offset int64) error {
+	if _, err := r.rs.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+	if r.br == nil {
+		r.br = bufio.NewReader(r.rs)
+	} else {
+		r.br.Reset(r.rs)
+	}
+	r.pos = offset
+	r.unreadBuf = nil
+	return nil
+}
+
+// unread puts b back in front of the unread input.
+func (r *seekReader) unread(b []byte) {
+	if len(b) == 0 {
+		return
+	}
+	buf := make([]byte, 0, len(b)+len(r.unreadBuf))
+	r.unreadBuf = append(append(buf, b...), r.unreadBuf...)
+	r.pos -= int64(len(b))
+}
+
+func (r *seekReader) Read(p []byte) (int, error) {
+	if len(r.unreadBuf) > 0 {
+		n := copy(p, r.unreadBuf)
+		r.unreadBuf = r.unreadBuf[n:]
+		r.pos += int64(n)
+		return n, nil
+	}
+
+	n, err := r.br.Read(p)
+	r.pos += int64(n)
+	return n, err
+}
+
+func (r *seekReader) ReadByte() (byte, error) {
+	if len(r.unreadBuf) > 0 {
+		b := r.unreadBuf[0]
+		r.unreadBuf = r.unreadBuf[1:]
+		r.pos++
+		return b, nil
+	}
+
+	b, err := r.br.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	r.pos++
+	return b, nil
+}
+
+// discard skips n bytes, seeking if they are not buffered.
+func (r *seekReader) discard(n int64) error {
+	if m := minInt(int(n), len(r.unreadBuf)); m > 0 {
+		r.unreadBuf = r.unreadBuf[m:]
+		r.pos += int64(m)
+		n -= int64(m)
+	}
+	if n <= int64(r.br.Buffered()) {
+		_, err := r.br.Discard(int(n))
+		r.pos += n
+		return err
+	}
+	return r.seek(r.pos + n)
+}
+
+// readN reads n bytes, returning the bytes read before any error.
+func (r *seekReader) readN(n int64) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	_, err := io.CopyN(buf, r, n)
+	return buf.Bytes(), err
+}
+
+// skipSync skips past the next sync marker, searching b before the input.
+// It returns io.EOF if there is no further sync marker.
+func (r *seekReader) skipSync(sync [16]byte, b []byte) error {
+	if i := bytes.Index(b, sync[:]); i >= 0 {
+		r.unread(b[i+syncSize:])
+		return nil
+	}
+
+	var window [syncSize]byte
+	n := copy(window[:], b[len(b)-minInt(len(b), syncSize-1):])
+	copy(window[syncSize-n:], window[:n])
+	for {
+		c, err := r.ReadByte()
+		if err != nil {
+			return err
+		}
+
+		copy(window[:], window[1:])
+		window[syncSize-1] = c
+		n++
+		if n >= syncSize && window == sync {
+			return nil
+		}
+	}
+}
+
+func (r *seekReader) readLong() (int64, error) {
+	return binary.ReadVarint(r)
+}
+
+// readBlockHeader reads the record count and data size of a block.
+// It returns io.EOF if there are no more blocks.
+func (r *seekReader) readBlockHeader() (int64, int64, error) {
+	count, err := r.readLong()
+	if err != nil {
+		return 0, 0, err
+	}
+	size, err := r.readLong()
+	if err != nil {
+		return 0, 0, unexpectedEOF(err)
+	}
+	if count < 0 || size < 0 {
+		return 0, 0, errors.New("invalid block")
+	}
+	return count, size, nil
+}
+
+func (r *seekReader) readSync(sync [16]byte) error {
+	var syncMark [16]byte
+	if _, err := io.ReadFull(r, syncMark[:]); err != nil {
+		return unexpectedEOF(err)
+	}
+	if syncMark != sync {
+		return errors.New("invalid block")
+	}
+	return nil
+}
+
+func (r *seekReader) readBytes() ([]byte, error) {
+	size, err := r.readLong()
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	if size < 0 {
+		return nil, errors.New("invalid bytes length")
+	}
+	if size > maxByteSliceSize {
+		return nil, fmt.Errorf("size is greater than the maximum of %d", maxByteSliceSize)
+	}
+	if size == 0 {
+		return []byte{}, nil
+	}
+
+	b, err := r.readN(size)
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	return b, nil
+}
+
+// readHeader reads the container file header, leaving the reader at the first data block.
+func (r *seekReader) readHeader() (*ocfHeader, error) {
+	h := Header{Meta: map[string][]byte{}}
+	if _, err := io.ReadFull(r, h.Magic[:]); err != nil {
+		return nil, fmt.Errorf("unexpected error: %w", unexpectedEOF(err))
+	}
+
+	for {
+		count, err := r.readLong()
+		if err != nil {
+			return nil, fmt.Errorf("unexpected error: %w", unexpectedEOF(err))
+		}
+		if count == 0 {
+			break
+		}
+		if count < 0 {
+			count = -count
+			if _, err = r.readLong(); err != nil {
+				return nil, fmt.Errorf("unexpected error: %w", unexpectedEOF(err))
+			}
+		}
+
+		for i := int64(0); i < count; i++ {
+			key, err := r.readBytes()
+			if err != nil {
+				return nil, fmt.Errorf("unexpected error: %w", err)
+			}
+			val, err := r.readBytes()
+			if err != nil {
+				return nil, fmt.Errorf("unexpected error: %w", err)
+			}
+			h.Meta[string(key)] = val
+		}
+	}
+
+	if _, err := io.ReadFull(r, h.Sync[:]); err != nil {
+		return nil, fmt.Errorf("unexpected error: %w", unexpectedEOF(err))
+	}
+
+	return newOCFHeader(h)
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func unexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,243 @@
+package ocf_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+
+	"github.com/kjuulh/avro/v2/ocf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type SeekRecord struct {
+	ID int64 `avro:"id"`
+}
+
+var seekSchema = `{"type":"record","name":"SeekRecord","fields":[{"name":"id","type":"long"}]}`
+
+func seekFile(t *testing.T, n int, opts ...ocf.EncoderFunc) []byte {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+	enc, err := ocf.NewEncoder(seekSchema, buf, append([]ocf.EncoderFunc{ocf.WithBlockLength(2)}, opts...)...)
+	require.NoError(t, err)
+	for i := 0; i < n; i++ {
+		require.NoError(t, enc.Encode(SeekRecord{ID: int64(i)}))
+	}
+	require.NoError(t, enc.Close())
+	return buf.Bytes()
+}
+
+// oversizedHeader returns a header declaring a metadata key larger than any the decoder reads.
+func oversizedHeader() []byte {
+	b := []byte{'O', 'b', 'j', 1, 0x02}
+	return binary.AppendVarint(b, 1<<40)
+}
+
+func readSeekRecords(t *testing.T, dec *ocf.SeekableDecoder) []int64 {
+	t.Helper()
+
+	var ids []int64
+	for dec.HasNext() {
+		var rec SeekRecord
+		require.NoError(t, dec.Decode(&rec))
+		ids = append(ids, rec.ID)
+	}
+	require.NoError(t, dec.Error())
+	return ids
+}
+
+func TestNewSeekableDecoder_InvalidHeader(t *testing.T) {
+	_, err := ocf.NewSeekableDecoder(bytes.NewReader([]byte{'O', 'b', 'j', 1, 0x02}))
+
+	assert.Error(t, err)
+}
+
+func TestNewSeekableDecoder_InvalidMagic(t *testing.T) {
+	data := seekFile(t, 1)
+	data[0] = 'o'
+
+	_, err := ocf.NewSeekableDecoder(bytes.NewReader(data))
+
+	assert.Error(t, err)
+}
+
+func TestNewSeekableDecoder_OversizedMetadata(t *testing.T) {
+	_, err := ocf.NewSeekableDecoder(bytes.NewReader(oversizedHeader()))
+
+	assert.ErrorContains(t, err, "size is greater than the maximum")
+}
+
+func TestSeekableDecoder(t *testing.T) {
+	data := seekFile(t, 5)
+
+	dec, err := ocf.NewSeekableDecoder(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	assert.Equal(t, []byte("null"), dec.Metadata()["avro.codec"])
+	assert.Equal(t, []int64{0, 1, 2, 3, 4}, readSeekRecords(t, dec))
+}
+
+func TestSeekableDecoder_OversizedBlock(t *testing.T) {
+	data := seekFile(t, 5)
+	dec, err := ocf.NewSeekableDecoder(bytes.NewReader(data))
+	require.NoError(t, err)
+	blocks, err := dec.Blocks()
+	require.NoError(t, err)
+
+	data = binary.AppendVarint(data[:blocks[0].Offset:blocks[0].Offset], 1)
+	data = binary.AppendVarint(data, 1<<40)
+	data = append(data, 0x02)
+	dec, err = ocf.NewSeekableDecoder(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	assert.False(t, dec.HasNext())
+	assert.ErrorIs(t, dec.Error(), io.ErrUnexpectedEOF)
+}
+
+func TestSeekableDecoder_Blocks(t *testing.T) {
+	data := seekFile(t, 5)
+
+	dec, err := ocf.NewSeekableDecoder(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	blocks, err := dec.Blocks()
+
+	require.NoError(t, err)
+	require.Len(t, blocks, 3)
+	assert.Equal(t, []int64{2, 2, 1}, []int64{blocks[0].Count, blocks[1].Count, blocks[2].Count})
+	assert.Equal(t, []int64{0, 2, 4}, []int64{blocks[0].Record, blocks[1].Record, blocks[2].Record})
+	assert.Equal(t, blocks[0].Offset+2+blocks[0].Size+16, blocks[1].Offset)
+	assert.Equal(t, int64(len(data)), blocks[2].Offset+2+blocks[2].Size+16)
+
+	assert.Equal(t, []int64{0, 1, 2, 3, 4}, readSeekRecords(t, dec))
+}
+
+func TestSeekableDecoder_BlocksInvalidSync(t *testing.T) {
+	data := seekFile(t, 5)
+	data[len(data)-1]++
+
+	dec, err := ocf.NewSeekableDecoder(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	_, err = dec.Blocks()
+
+	assert.Error(t, err)
+}
+
+func TestSeekableDecoder_SeekToBlock(t *testing.T) {
+	data := seekFile(t, 5, ocf.WithCodec(ocf.Deflate))
+
+	dec, err := ocf.NewSeekableDecoder(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	err = dec.SeekToBlock(1)
+	require.NoError(t, err)
+
+	require.True(t, dec.HasNext())
+	blocks, err := dec.Blocks()
+	require.NoError(t, err)
+	assert.Equal(t, blocks[1], dec.Block())
+	assert.Equal(t, []int64{2, 3, 4}, readSeekRecords(t, dec))
+}
+
+func TestSeekableDecoder_SeekToBlockOutOfRange(t *testing.T) {
+	data := seekFile(t, 5)
+
+	dec, err := ocf.NewSeekableDecoder(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	err = dec.SeekToBlock(3)
+
+	assert.Error(t, err)
+}
+
+func TestSeekableDecoder_SeekToRecord(t *testing.T) {
+	data := seekFile(t, 5)
+
+	dec, err := ocf.NewSeekableDecoder(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	err = dec.SeekToRecord(3)
+	require.NoError(t, err)
+	require.True(t, dec.HasNext())
+	var rec SeekRecord
+	require.NoError(t, dec.Decode(&rec))
+	assert.Equal(t, int64(3), rec.ID)
+
+	err = dec.SeekToRecord(0)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{0, 1, 2, 3, 4}, readSeekRecords(t, dec))
+}
+
+func TestSeekableDecoder_SeekToRecordTruncatedBlock(t *testing.T) {
+	data := seekFile(t, 5)
+	dec, err := ocf.NewSeekableDecoder(bytes.NewReader(data))
+	require.NoError(t, err)
+	blocks, err := dec.Blocks()
+	require.NoError(t, err)
+
+	sync := data[blocks[0].Offset-16 : blocks[0].Offset]
+	data = binary.AppendVarint(data[:blocks[0].Offset:blocks[0].Offset], 3)
+	data = binary.AppendVarint(data, 1)
+	data = append(data, 0x02)
+	data = append(data, sync...)
+	dec, err = ocf.NewSeekableDecoder(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	err = dec.SeekToRecord(2)
+
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	assert.False(t, dec.HasNext())
+}
+
+func TestSeekableDecoder_SeekToRecordOutOfRange(t *testing.T) {
+	data := seekFile(t, 5)
+
+	dec, err := ocf.NewSeekableDecoder(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	err = dec.SeekToRecord(5)
+
+	assert.Error(t, err)
+}
+
+func TestSeekableDecoder_Splits(t *testing.T) {
+	data := seekFile(t, 25)
+
+	dec, err := ocf.NewSeekableDecoderAt(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+
+	var ids []int64
+	splitSize := int64(7)
+	for start := int64(0); start < int64(len(data)); start += splitSize {
+		require.NoError(t, dec.Sync(start))
+		for dec.HasNext() && !dec.PastSync(start+splitSize) {
+			var rec SeekRecord
+			require.NoError(t, dec.Decode(&rec))
+			ids = append(ids, rec.ID)
+		}
+		require.NoError(t, dec.Error())
+	}
+
+	want := make([]int64, 25)
+	for i := range want {
+		want[i] = int64(i)
+	}
+	assert.Equal(t, want, ids)
+}
+
+func TestSeekableDecoder_SyncPastEnd(t *testing.T) {
+	data := seekFile(t, 5)
+
+	dec, err := ocf.NewSeekableDecoder(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	err = dec.Sync(int64(len(data) - 10))
+
+	require.NoError(t, err)
+	assert.False(t, dec.HasNext())
+	assert.NoError(t, dec.Error())
+}
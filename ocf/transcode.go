@@ -0,0 +1,88 @@
+package ocf
+
+import (
+	"io"
+	"strings"
+
+	"github.com/kjuulh/avro/v2"
+)
+
+// TranscodeConfig configures the container file written by Transcode.
+type TranscodeConfig struct {
+	// Codec is the compression codec of the new file.
+	// If empty, the codec of the source file is used.
+	Codec CodecName
+	// CompressionLevel is the compression level passed to the codec.
+	// If zero, the codec default is used.
+	CompressionLevel int
+	// BlockLength is the number of values in a block of the new file.
+	// If zero, the encoder default is used.
+	BlockLength int
+	// ReaderSchema is the schema of the new file. The values of the source
+	// file are resolved into it. If nil, the source schema is kept.
+	ReaderSchema avro.Schema
+}
+
+// Transcode reads the container file from r and writes its values to a new
+// container file in w, using the codec, block length and schema in cfg.
+// The user metadata of the source file is kept.
+func Transcode(w io.Writer, r io.Reader, cfg TranscodeConfig) error {
+	var decOpts []DecoderFunc
+	if cfg.ReaderSchema != nil {
+		decOpts = append(decOpts, WithReaderSchema(cfg.ReaderSchema))
+	}
+	dec, err := NewDecoder(r, decOpts...)
+	if err != nil {
+		return err
+	}
+
+	schema := dec.writerSchema
+	if cfg.ReaderSchema != nil {
+		schema = cfg.ReaderSchema
+	}
+	codec := cfg.Codec
+	if codec == "" {
+		codec = codecNameOf(dec.meta)
+	}
+	lvl := cfg.CompressionLevel
+	if lvl == 0 {
+		lvl = -1
+	}
+
+	meta := map[string][]byte{}
+	for k, v := range dec.meta {
+		// Keys starting with "avro." are reserved, and set by the encoder.
+		if strings.HasPrefix(k, "avro.") {
+			continue
+		}
+		meta[k] = v
+	}
+
+	encOpts := []EncoderFunc{
+		WithMetadata(meta),
+		WithCodec(codec),
+		WithCodecLevel(lvl),
+	}
+	if cfg.BlockLength > 0 {
+		encOpts = append(encOpts, WithBlockLength(cfg.BlockLength))
+	}
+	enc, err := NewEncoder(schema.String(), w, encOpts...)
+	if err != nil {
+		return err
+	}
+
+	for dec.HasNext() {
+		var v any
+		if err = dec.Decode(&v); err != nil {
+			return err
+		}
+		if err = enc.Encode(v); err != nil {
+			return err
+		}
+	}
+	if err = dec.Error(); err != nil {
+		return err
+	}
+
+	return enc.Close()
+}
@@ -0,0 +1,184 @@
+package ocf_test
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/kjuulh/avro/v2"
+	"github.com/kjuulh/avro/v2/ocf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranscode(t *testing.T) {
+	data := seekFile(t, 7, ocf.WithCodec(ocf.Deflate), ocf.WithMetadata(map[string][]byte{"foo": []byte("bar")}))
+
+	buf := &bytes.Buffer{}
+	err := ocf.Transcode(buf, bytes.NewReader(data), ocf.TranscodeConfig{
+		Codec:            ocf.ZStandard,
+		CompressionLevel: 9,
+		BlockLength:      3,
+	})
+	require.NoError(t, err)
+
+	r, err := ocf.NewBlockReader(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, ocf.ZStandard, r.Codec())
+	assert.Equal(t, []byte("bar"), r.Metadata()["foo"])
+	var counts []int64
+	for r.Next() {
+		counts = append(counts, r.Block().Count)
+	}
+	require.NoError(t, r.Error())
+	assert.Equal(t, []int64{3, 3, 1}, counts)
+
+	dec, err := ocf.NewSeekableDecoder(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, []int64{0, 1, 2, 3, 4, 5, 6}, readSeekRecords(t, dec))
+}
+
+func TestTranscode_CompressionLevel(t *testing.T) {
+	var lvls []int
+	ocf.RegisterCodec("transcode-level", func(lvl int) (ocf.Codec, error) {
+		lvls = append(lvls, lvl)
+		return &ocf.ZStandardCodec{}, nil
+	})
+	data := seekFile(t, 3)
+
+	buf := &bytes.Buffer{}
+	err := ocf.Transcode(buf, bytes.NewReader(data), ocf.TranscodeConfig{
+		Codec:            "transcode-level",
+		CompressionLevel: 19,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []int{19}, lvls)
+	dec, err := ocf.NewSeekableDecoder(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, []int64{0, 1, 2}, readSeekRecords(t, dec))
+}
+
+func TestTranscode_KeepsCodec(t *testing.T) {
+	data := seekFile(t, 3, ocf.WithCodec(ocf.Snappy))
+
+	buf := &bytes.Buffer{}
+	err := ocf.Transcode(buf, bytes.NewReader(data), ocf.TranscodeConfig{})
+	require.NoError(t, err)
+
+	r, err := ocf.NewBlockReader(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, ocf.Snappy, r.Codec())
+}
+
+func TestTranscode_FullRecord(t *testing.T) {
+	want := readFullRecords(t, "testdata/full-snappy.avro")
+
+	f, err := os.Open("testdata/full-snappy.avro")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = f.Close() })
+
+	buf := &bytes.Buffer{}
+	err = ocf.Transcode(buf, f, ocf.TranscodeConfig{Codec: ocf.XZ})
+	require.NoError(t, err)
+
+	dec, err := ocf.NewDecoder(buf)
+	require.NoError(t, err)
+	var got []FullRecord
+	for dec.HasNext() {
+		var rec FullRecord
+		require.NoError(t, dec.Decode(&rec))
+		got = append(got, rec)
+	}
+	require.NoError(t, dec.Error())
+	assert.Equal(t, want, got)
+}
+
+func TestTranscode_ReaderSchema(t *testing.T) {
+	type Record struct {
+		ID   int64  `avro:"id"`
+		Name string `avro:"name"`
+	}
+
+	data := seekFile(t, 2)
+	readerSchema := avro.MustParse(`{"type":"record","name":"SeekRecord","fields":[
+		{"name":"id","type":"long"},
+		{"name":"name","type":"string","default":"none"}
+	]}`)
+
+	buf := &bytes.Buffer{}
+	err := ocf.Transcode(buf, bytes.NewReader(data), ocf.TranscodeConfig{ReaderSchema: readerSchema})
+	require.NoError(t, err)
+
+	dec, err := ocf.NewDecoder(buf)
+	require.NoError(t, err)
+	assert.Equal(t, readerSchema.String(), string(dec.Metadata()["avro.schema"]))
+	var got []Record
+	for dec.HasNext() {
+		var rec Record
+		require.NoError(t, dec.Decode(&rec))
+		got = append(got, rec)
+	}
+	require.NoError(t, dec.Error())
+	assert.Equal(t, []Record{{ID: 0, Name: "none"}, {ID: 1, Name: "none"}}, got)
+}
+
+func TestTranscode_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		cfg  ocf.TranscodeConfig
+	}{
+		{
+			name: "invalid file",
+			data: []byte{'O', 'b', 'j', 1, 0x02},
+		},
+		{
+			name: "incompatible reader schema",
+			data: seekFile(t, 2),
+			cfg:  ocf.TranscodeConfig{ReaderSchema: avro.MustParse(`"string"`)},
+		},
+		{
+			name: "unknown codec",
+			data: seekFile(t, 2),
+			cfg:  ocf.TranscodeConfig{Codec: "test"},
+		},
+		{
+			name: "invalid block",
+			data: func() []byte {
+				data := seekFile(t, 2)
+				data[len(data)-1]++
+				return data
+			}(),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			err := ocf.Transcode(&bytes.Buffer{}, bytes.NewReader(test.data), test.cfg)
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+func readFullRecords(t *testing.T, path string) []FullRecord {
+	t.Helper()
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = f.Close() })
+
+	dec, err := ocf.NewDecoder(f)
+	require.NoError(t, err)
+
+	var recs []FullRecord
+	for dec.HasNext() {
+		var rec FullRecord
+		require.NoError(t, dec.Decode(&rec))
+		recs = append(recs, rec)
+	}
+	require.NoError(t, dec.Error())
+	return recs
+}
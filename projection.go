@@ -0,0 +1,233 @@
+package avro
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Project returns a schema that decodes only the fields at the given paths,
+// skipping all other fields in the data.
+//
+// A path is a dot separated list of record field names, e.g. "user.address.zip".
+// The elements of an array or the values of a map are selected with "[]",
+// e.g. "items[].sku". Selecting a field selects everything beneath it.
+//
+// The projected schema can be used to decode into a struct or map with
+// Unmarshal or a Decoder. It must not be used to encode data.
+func Project(schema Schema, paths ...string) (Schema, error) {
+	if len(paths) == 0 {
+		return nil, errors.New("avro: at least one projection path is required")
+	}
+
+	root := &projectionNode{}
+	for _, path := range paths {
+		if err := root.add(path); err != nil {
+			return nil, err
+		}
+	}
+
+	return projectSchema(schema, root)
+}
+
+const projectionElem = "[]"
+
+// projectionNode is a node in the tree of selected paths.
+type projectionNode struct {
+	path     string
+	all      bool
+	children map[string]*projectionNode
+}
+
+func (n *projectionNode) add(path string) error {
+	segments, err := splitProjectionPath(path)
+	if err != nil {
+		return err
+	}
+
+	node := n
+	for _, seg := range segments {
+		if node.all {
+			return nil
+		}
+
+		child, ok := node.children[seg]
+		if !ok {
+			if node.children == nil {
+				node.children = map[string]*projectionNode{}
+			}
+			child = &projectionNode{path: joinProjectionPath(node.path, seg)}
+			node.children[seg] = child
+		}
+		node = child
+	}
+	node.all = true
+	node.children = nil
+	return nil
+}
+
+func splitProjectionPath(path string) ([]string, error) {
+	var segments []string
+	for _, part := range strings.Split(path, ".") {
+		name, elems := part, 0
+		for strings.HasSuffix(name, projectionElem) {
+			name = strings.TrimSuffix(name, projectionElem)
+			elems++
+		}
+		if name == "" || strings.ContainsAny(name, "[]") {
+			return nil, fmt.Errorf("avro: invalid projection path %q", path)
+		}
+
+		segments = append(segments, name)
+		for i := 0; i < elems; i++ {
+			segments = append(segments, projectionElem)
+		}
+	}
+	return segments, nil
+}
+
+func joinProjectionPath(path, seg string) string {
+	if path == "" || seg == projectionElem {
+		return path + seg
+	}
+	return path + "." + seg
+}
+
+func projectSchema(schema Schema, node *projectionNode) (Schema, error) {
+	if node.all {
+		return schema, nil
+	}
+
+	if schema.Type() == Ref {
+		schema = schema.(*RefSchema).Schema()
+	}
+
+	switch schema.Type() {
+	case Record:
+		return projectRecord(schema.(*RecordSchema), node)
+
+	case Array:
+		child, err := node.elem(schema)
+		if err != nil {
+			return nil, err
+		}
+		items, err := projectSchema(schema.(*ArraySchema).Items(), child)
+		if err != nil {
+			return nil, err
+		}
+		return NewArraySchema(items), nil
+
+	case Map:
+		child, err := node.elem(schema)
+		if err != nil {
+			return nil, err
+		}
+		values, err := projectSchema(schema.(*MapSchema).Values(), child)
+		if err != nil {
+			return nil, err
+		}
+		return NewMapSchema(values), nil
+
+	case Union:
+		return projectUnion(schema.(*UnionSchema), node)
+
+	default:
+		return nil, fmt.Errorf("avro: cannot select %s beneath %s at %q", node.childNames(), schema.Type(), node.path)
+	}
+}
+
+func (n *projectionNode) elem(schema Schema) (*projectionNode, error) {
+	child, ok := n.children[projectionElem]
+	if !ok || len(n.children) > 1 {
+		return nil, fmt.Errorf("avro: %s at %q must be selected with %q", schema.Type(), n.path, projectionElem)
+	}
+	return child, nil
+}
+
+func (n *projectionNode) childNames() string {
+	names := make([]string, 0, len(n.children))
+	for name := range n.children {
+		names = append(names, name)
+	}
+	return strings.Join(names, ", ")
+}
+
+func projectRecord(rec *RecordSchema, node *projectionNode) (Schema, error) {
+	fields := make([]*Field, 0, len(rec.Fields()))
+	seen := make(map[string]struct{}, len(node.children))
+	for _, field := range rec.Fields() {
+		child, ok := node.children[field.Name()]
+		if !ok {
+			switch field.action {
+			case FieldSetDefault:
+				// The field is not in the data, there is nothing to skip.
+			default:
+				fields = append(fields, copyField(field, field.Type(), FieldIgnore))
+			}
+			continue
+		}
+		seen[field.Name()] = struct{}{}
+
+		if field.action == FieldIgnore {
+			return nil, fmt.Errorf("avro: field %q is not in the data", child.path)
+		}
+
+		typ := field.Type()
+		if field.action != FieldSetDefault {
+			var err error
+			typ, err = projectSchema(typ, child)
+			if err != nil {
+				return nil, err
+			}
+		}
+		fields = append(fields, copyField(field, typ, field.action))
+	}
+
+	for name, child := range node.children {
+		if _, ok := seen[name]; !ok {
+			return nil, fmt.Errorf("avro: unknown field %q in record %s", child.path, rec.FullName())
+		}
+	}
+
+	return NewRecordSchema(rec.Name(), rec.Namespace(), fields, WithAliases(rec.Aliases()))
+}
+
+func copyField(field *Field, typ Schema, action Action) *Field {
+	f, _ := NewField(field.Name(), typ, WithAliases(field.Aliases()), WithOrder(field.Order()))
+	f.def = field.def
+	f.hasDef = field.hasDef
+	f.action = action
+	return f
+}
+
+func projectUnion(union *UnionSchema, node *projectionNode) (Schema, error) {
+	var (
+		firstErr  error
+		projected bool
+	)
+	types := make([]Schema, len(union.Types()))
+	for i, typ := range union.Types() {
+		types[i] = typ
+		if typ.Type() == Null {
+			continue
+		}
+
+		sch, err := projectSchema(typ, node)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		types[i] = sch
+		projected = true
+	}
+	if !projected {
+		if firstErr == nil {
+			firstErr = fmt.Errorf("avro: cannot select %s beneath null at %q", node.childNames(), node.path)
+		}
+		return nil, firstErr
+	}
+
+	return &UnionSchema{types: types, resolved: union.resolved, resolvedIdx: union.resolvedIdx}, nil
+}
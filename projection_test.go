@@ -0,0 +1,212 @@
+package avro_test
+
+import (
+	"testing"
+
+	"github.com/kjuulh/avro/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testProjectionSchema = `{
+	"type": "record",
+	"name": "order",
+	"fields" : [
+		{"name": "id", "type": "long"},
+		{"name": "user", "type": {
+			"type": "record",
+			"name": "user",
+			"fields": [
+				{"name": "name", "type": "string"},
+				{"name": "address", "type": {
+					"type": "record",
+					"name": "address",
+					"fields": [
+						{"name": "street", "type": "string"},
+						{"name": "zip", "type": "string"}
+					]
+				}}
+			]
+		}},
+		{"name": "items", "type": {"type": "array", "items": {
+			"type": "record",
+			"name": "item",
+			"fields": [
+				{"name": "sku", "type": "string"},
+				{"name": "qty", "type": "int"}
+			]
+		}}},
+		{"name": "meta", "type": {"type": "map", "values": "item"}},
+		{"name": "parent", "type": ["null", "item"]},
+		{"name": "note", "type": "string"}
+	]
+}`
+
+type TestProjectionAddress struct {
+	Street string `avro:"street"`
+	Zip    string `avro:"zip"`
+}
+
+type TestProjectionUser struct {
+	Name    string                `avro:"name"`
+	Address TestProjectionAddress `avro:"address"`
+}
+
+type TestProjectionItem struct {
+	SKU string `avro:"sku"`
+	Qty int    `avro:"qty"`
+}
+
+type TestProjectionOrder struct {
+	ID     int64                         `avro:"id"`
+	User   TestProjectionUser            `avro:"user"`
+	Items  []TestProjectionItem          `avro:"items"`
+	Meta   map[string]TestProjectionItem `avro:"meta"`
+	Parent *TestProjectionItem           `avro:"parent"`
+	Note   string                        `avro:"note"`
+}
+
+func testProjectionData(t *testing.T) (avro.Schema, []byte) {
+	t.Helper()
+
+	schema := avro.MustParse(testProjectionSchema)
+	data, err := avro.Marshal(schema, TestProjectionOrder{
+		ID: 1,
+		User: TestProjectionUser{
+			Name:    "foo",
+			Address: TestProjectionAddress{Street: "main", Zip: "1234"},
+		},
+		Items:  []TestProjectionItem{{SKU: "a", Qty: 1}, {SKU: "b", Qty: 2}},
+		Meta:   map[string]TestProjectionItem{"x": {SKU: "c", Qty: 3}},
+		Parent: &TestProjectionItem{SKU: "d", Qty: 4},
+		Note:   "bar",
+	})
+	require.NoError(t, err)
+	return schema, data
+}
+
+func TestProject_Struct(t *testing.T) {
+	defer ConfigTeardown()
+
+	schema, data := testProjectionData(t)
+
+	projected, err := avro.Project(schema, "user.address.zip", "items[].sku", "note")
+	require.NoError(t, err)
+
+	var got TestProjectionOrder
+	err = avro.Unmarshal(projected, data, &got)
+
+	require.NoError(t, err)
+	want := TestProjectionOrder{
+		User:  TestProjectionUser{Address: TestProjectionAddress{Zip: "1234"}},
+		Items: []TestProjectionItem{{SKU: "a"}, {SKU: "b"}},
+		Note:  "bar",
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestProject_Map(t *testing.T) {
+	defer ConfigTeardown()
+
+	schema, data := testProjectionData(t)
+
+	projected, err := avro.Project(schema, "id", "user.name", "meta[].qty", "parent.sku")
+	require.NoError(t, err)
+
+	var got map[string]any
+	err = avro.Unmarshal(projected, data, &got)
+
+	require.NoError(t, err)
+	want := map[string]any{
+		"id":     int64(1),
+		"user":   map[string]any{"name": "foo"},
+		"meta":   map[string]any{"x": map[string]any{"qty": 3}},
+		"parent": map[string]any{"item": map[string]any{"sku": "d"}},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestProject_WholeSubtree(t *testing.T) {
+	defer ConfigTeardown()
+
+	schema, data := testProjectionData(t)
+
+	projected, err := avro.Project(schema, "user.address", "user.address.zip", "parent")
+	require.NoError(t, err)
+
+	var got TestProjectionOrder
+	err = avro.Unmarshal(projected, data, &got)
+
+	require.NoError(t, err)
+	want := TestProjectionOrder{
+		User:   TestProjectionUser{Address: TestProjectionAddress{Street: "main", Zip: "1234"}},
+		Parent: &TestProjectionItem{SKU: "d", Qty: 4},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestProject_DoesNotShareFullDecoder(t *testing.T) {
+	defer ConfigTeardown()
+
+	schema, data := testProjectionData(t)
+	projected, err := avro.Project(schema, "note")
+	require.NoError(t, err)
+
+	var full TestProjectionOrder
+	require.NoError(t, avro.Unmarshal(schema, data, &full))
+	var got TestProjectionOrder
+	err = avro.Unmarshal(projected, data, &got)
+
+	require.NoError(t, err)
+	assert.Equal(t, TestProjectionOrder{Note: "bar"}, got)
+}
+
+func TestProject_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		paths []string
+	}{
+		{
+			name: "no paths",
+		},
+		{
+			name:  "empty segment",
+			paths: []string{"user..name"},
+		},
+		{
+			name:  "invalid brackets",
+			paths: []string{"items[0].sku"},
+		},
+		{
+			name:  "unknown field",
+			paths: []string{"user.age"},
+		},
+		{
+			name:  "field beneath primitive",
+			paths: []string{"note.length"},
+		},
+		{
+			name:  "array without elements",
+			paths: []string{"items.sku"},
+		},
+		{
+			name:  "elements of record",
+			paths: []string{"user[].name"},
+		},
+		{
+			name:  "unknown field in union",
+			paths: []string{"parent.name"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			schema := avro.MustParse(testProjectionSchema)
+
+			_, err := avro.Project(schema, test.paths...)
+
+			assert.Error(t, err)
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 
 	jsoniter "github.com/json-iterator/go"
 	"github.com/mitchellh/mapstructure"
@@ -211,6 +212,29 @@ func ParseProtocolFile(path string) (*Protocol, error) {
 	return ParseProtocol(string(s))
 }
 
+// ParseIDLFile parses an Avro protocol from an Avro IDL file.
+//
+// Imports are resolved relative to the directory of the file.
+func ParseIDLFile(path string) (*Protocol, error) {
+	s, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseIDL(string(s), filepath.Dir(abs), map[string]struct{}{abs: {}})
+}
+
+// ParseIDL parses an Avro protocol from Avro IDL.
+//
+// Imports are resolved relative to the current working directory.
+func ParseIDL(idl string) (*Protocol, error) {
+	return parseIDL(idl, "", map[string]struct{}{})
+}
+
 // MustParseProtocol parses an Avro protocol, panicing if there is an error.
 func MustParseProtocol(protocol string) *Protocol {
 	parsed, err := ParseProtocol(protocol)
@@ -32,6 +32,9 @@ type Reader struct {
 	head   int
 	tail   int
 	Error  error
+
+	// zeroCopy is set when buf is caller owned input that decoded values may reference.
+	zeroCopy bool
 }
 
 // NewReader creates a new Reader.
@@ -57,6 +60,7 @@ func (r *Reader) Reset(b []byte) *Reader {
 	r.buf = b
 	r.head = 0
 	r.tail = len(b)
+	r.zeroCopy = r.cfg != nil && r.cfg.config.ZeroCopy
 	return r
 }
 
@@ -230,6 +234,13 @@ func (r *Reader) readBytes(op string) []byte {
 		return nil
 	}
 
+	// The bytes are entirely in a caller owned buffer, reference it.
+	if r.zeroCopy && r.head+size <= r.tail {
+		dst := r.buf[r.head : r.head+size : r.head+size]
+		r.head += size
+		return dst
+	}
+
 	// The bytes are entirely in the buffer and of a reasonable size.
 	// Use the byte slab.
 	if r.head+size <= r.tail && size <= 1024 {
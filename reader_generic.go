@@ -96,8 +96,12 @@ func (r *Reader) ReadNext(schema Schema) any {
 		})
 		return obj
 	case Union:
-		types := schema.(*UnionSchema).Types()
-		idx := int(r.ReadLong())
+		union := schema.(*UnionSchema)
+		types := union.Types()
+		idx := union.resolvedIdx
+		if !union.resolved {
+			idx = int(r.ReadLong())
+		}
 		if idx < 0 || idx > len(types)-1 {
 			r.ReportError("Read", "unknown union type")
 			return nil
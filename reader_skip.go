@@ -1,5 +1,10 @@
 package avro
 
+// SkipVal skips an Avro value of the given schema in the reader, without decoding it.
+func (r *Reader) SkipVal(schema Schema) {
+	r.cfg.skipDecoderOf(schema).Decode(nil, r)
+}
+
 // SkipNBytes skips the given number of bytes in the reader.
 func (r *Reader) SkipNBytes(n int) {
 	read := 0
@@ -28,6 +28,27 @@ func TestReader_SkipNBytesEOF(t *testing.T) {
 	assert.Error(t, r.Error)
 }
 
+func TestReader_SkipVal(t *testing.T) {
+	schema := avro.MustParse(`{"type":"record","name":"test","fields":[{"name":"a","type":"string"},{"name":"b","type":{"type":"array","items":"long"}}]}`)
+	data := []byte{0x06, 0x66, 0x6f, 0x6f, 0x04, 0x02, 0x04, 0x00, 0x02, 0x61, 0x36}
+	r := avro.NewReader(bytes.NewReader(data), 2)
+
+	r.SkipVal(schema)
+	r.SkipVal(schema.(*avro.RecordSchema).Fields()[0].Type())
+
+	require.NoError(t, r.Error)
+	assert.Equal(t, int32(27), r.ReadInt())
+}
+
+func TestReader_SkipValEOF(t *testing.T) {
+	schema := avro.MustParse(`"string"`)
+	r := avro.NewReader(bytes.NewReader([]byte{0x06, 0x66}), 2)
+
+	r.SkipVal(schema)
+
+	assert.Error(t, r.Error)
+}
+
 func TestReader_SkipBool(t *testing.T) {
 	data := []byte{0x01, 0x36}
 	r := avro.NewReader(bytes.NewReader(data), 10)
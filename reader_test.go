@@ -663,3 +663,30 @@ func (r *delayedReader) Read(p []byte) (n int, err error) {
 
 	return copy(p, r.b), nil
 }
+
+func TestReader_ReadBytesZeroCopy(t *testing.T) {
+	api := avro.Config{ZeroCopy: true}.Freeze()
+	data := []byte{0x04, 0x03, 0xFF, 0x01}
+	r := avro.NewReader(nil, 0, avro.WithReaderConfig(api)).Reset(data)
+
+	got := r.ReadBytes()
+
+	require.NoError(t, r.Error)
+	assert.Equal(t, []byte{0x03, 0xFF}, got)
+	assert.Equal(t, 2, cap(got))
+	data[1] = 0x04
+	assert.Equal(t, []byte{0x04, 0xFF}, got)
+}
+
+func TestReader_ReadStringZeroCopy(t *testing.T) {
+	api := avro.Config{ZeroCopy: true}.Freeze()
+	data := []byte{0x06, 0x66, 0x6F, 0x6F}
+	r := avro.NewReader(nil, 0, avro.WithReaderConfig(api)).Reset(data)
+
+	got := r.ReadString()
+
+	require.NoError(t, r.Error)
+	assert.Equal(t, "foo", got)
+	data[1] = 0x62
+	assert.Equal(t, "boo", got)
+}
@@ -0,0 +1,129 @@
+package registry
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net/http"
+	"sync"
+)
+
+// AuthProvider authenticates requests to the registry.
+type AuthProvider interface {
+	// Authenticate adds the authentication to a request.
+	// It is called before every request.
+	Authenticate(req *http.Request) error
+
+	// Refresh refreshes the authentication after the registry
+	// rejects a request as unauthorized. The request is then retried once.
+	Refresh(ctx context.Context) error
+}
+
+// TokenFunc returns a bearer token.
+type TokenFunc func(ctx context.Context) (string, error)
+
+type bearerAuth struct {
+	fn TokenFunc
+
+	mu    sync.Mutex
+	token string
+}
+
+// BearerAuth returns an AuthProvider that authenticates requests with a bearer token.
+// The token is fetched on the first request, and fetched again when the
+// registry rejects it, allowing rotating tokens to be used.
+func BearerAuth(fn TokenFunc) AuthProvider {
+	return &bearerAuth{fn: fn}
+}
+
+func (a *bearerAuth) Authenticate(req *http.Request) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.token == "" {
+		token, err := a.fn(req.Context())
+		if err != nil {
+			return err
+		}
+		a.token = token
+	}
+
+	req.Header.Set("Authorization", "Bearer "+a.token)
+	return nil
+}
+
+func (a *bearerAuth) Refresh(ctx context.Context) error {
+	token, err := a.fn(ctx)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.token = token
+	return nil
+}
+
+// WithAuth sets the provider used to authenticate requests.
+func WithAuth(p AuthProvider) ClientFunc {
+	return func(c *Client) {
+		c.auth = p
+	}
+}
+
+// WithHeaders sets headers to add to every request.
+func WithHeaders(headers http.Header) ClientFunc {
+	return func(c *Client) {
+		for k, v := range headers {
+			for _, vv := range v {
+				c.headers.Add(k, vv)
+			}
+		}
+	}
+}
+
+// WithTLSConfig sets the tls config used to connect to the registry.
+//
+// The http client transport must be an *http.Transport.
+func WithTLSConfig(cfg *tls.Config) ClientFunc {
+	return func(c *Client) {
+		c.tlsConfig = cfg
+	}
+}
+
+// WithClientCertificate sets a certificate to present to the registry,
+// for mutual tls authentication.
+//
+// The http client transport must be an *http.Transport.
+func WithClientCertificate(cert tls.Certificate) ClientFunc {
+	return func(c *Client) {
+		c.certs = append(c.certs, cert)
+	}
+}
+
+// configureTLS returns a copy of the http client, with its transport configured
+// with the tls config and client certificates.
+func configureTLS(client *http.Client, cfg *tls.Config, certs []tls.Certificate) (*http.Client, error) {
+	rt := client.Transport
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	transport, ok := rt.(*http.Transport)
+	if !ok {
+		return nil, errors.New("registry: tls options require an *http.Transport")
+	}
+	transport = transport.Clone()
+
+	switch {
+	case cfg != nil:
+		transport.TLSClientConfig = cfg.Clone()
+	case transport.TLSClientConfig == nil:
+		transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	transport.TLSClientConfig.Certificates = append(transport.TLSClientConfig.Certificates, certs...)
+
+	c := *client
+	c.Transport = transport
+	return &c, nil
+}
@@ -0,0 +1,200 @@
+package registry_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/kjuulh/avro/v2/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_WithAuthBearerRefreshesToken(t *testing.T) {
+	var requests []string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		requests = append(requests, auth)
+		if auth != "Bearer token-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error_code":40101,"message":"Unauthorized"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`["foobar"]`))
+	}))
+	t.Cleanup(s.Close)
+
+	var tokens int
+	fn := func(ctx context.Context) (string, error) {
+		tokens++
+		return "token-" + strconv.Itoa(tokens), nil
+	}
+	client, _ := registry.NewClient(s.URL, registry.WithAuth(registry.BearerAuth(fn)))
+
+	subjects, err := client.GetSubjects(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"foobar"}, subjects)
+
+	_, err = client.GetSubjects(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, tokens)
+	assert.Equal(t, []string{"Bearer token-1", "Bearer token-2", "Bearer token-2"}, requests)
+}
+
+func TestClient_WithAuthRetriesOnce(t *testing.T) {
+	var calls int
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error_code":40101,"message":"Unauthorized"}`))
+	}))
+	t.Cleanup(s.Close)
+	fn := func(ctx context.Context) (string, error) {
+		return "token", nil
+	}
+	client, _ := registry.NewClient(s.URL, registry.WithAuth(registry.BearerAuth(fn)))
+
+	_, err := client.GetSubjects(context.Background())
+
+	var regErr registry.Error
+	require.ErrorAs(t, err, &regErr)
+	assert.Equal(t, http.StatusUnauthorized, regErr.StatusCode)
+	assert.Equal(t, 2, calls)
+}
+
+func TestClient_WithAuthRefreshError(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	t.Cleanup(s.Close)
+	var calls int
+	fn := func(ctx context.Context) (string, error) {
+		calls++
+		if calls > 1 {
+			return "", errors.New("test")
+		}
+		return "token", nil
+	}
+	client, _ := registry.NewClient(s.URL, registry.WithAuth(registry.BearerAuth(fn)))
+
+	_, err := client.GetSubjects(context.Background())
+
+	assert.EqualError(t, err, "could not refresh authentication: test")
+}
+
+func TestClient_WithAuthError(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.FailNow(t, "unexpected call")
+	}))
+	t.Cleanup(s.Close)
+	fn := func(ctx context.Context) (string, error) {
+		return "", errors.New("test")
+	}
+	client, _ := registry.NewClient(s.URL, registry.WithAuth(registry.BearerAuth(fn)))
+
+	_, err := client.GetSubjects(context.Background())
+
+	assert.EqualError(t, err, "could not authenticate request: test")
+}
+
+func TestClient_WithHeaders(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secret", r.Header.Get("X-Api-Key"))
+		assert.Equal(t, []string{"a", "b"}, r.Header.Values("X-Tenant"))
+		assert.Equal(t, "application/vnd.schemaregistry.v1+json", r.Header.Get("Content-Type"))
+
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	t.Cleanup(s.Close)
+	headers := http.Header{}
+	headers.Set("X-Api-Key", "secret")
+	headers.Add("X-Tenant", "a")
+	headers.Add("X-Tenant", "b")
+	client, _ := registry.NewClient(s.URL, registry.WithHeaders(headers))
+
+	_, err := client.GetSubjects(context.Background())
+
+	assert.NoError(t, err)
+}
+
+func TestClient_WithClientCertificate(t *testing.T) {
+	cert, pool := newCertificate(t)
+
+	s := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`["foobar"]`))
+	}))
+	s.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: pool, MinVersion: tls.VersionTLS12}
+	s.StartTLS()
+	t.Cleanup(s.Close)
+	roots := x509.NewCertPool()
+	roots.AddCert(s.Certificate())
+	tlsCfg := &tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12}
+
+	client, err := registry.NewClient(s.URL, registry.WithTLSConfig(tlsCfg))
+	require.NoError(t, err)
+	_, err = client.GetSubjects(context.Background())
+	assert.Error(t, err)
+
+	client, err = registry.NewClient(s.URL, registry.WithTLSConfig(tlsCfg), registry.WithClientCertificate(cert))
+	require.NoError(t, err)
+	subjects, err := client.GetSubjects(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"foobar"}, subjects)
+	assert.Empty(t, tlsCfg.Certificates)
+}
+
+func TestClient_WithClientCertificateRequiresTransport(t *testing.T) {
+	cert, _ := newCertificate(t)
+	httpClient := &http.Client{Transport: roundTripFunc(http.DefaultTransport.RoundTrip)}
+
+	_, err := registry.NewClient("https://example.com",
+		registry.WithHTTPClient(httpClient),
+		registry.WithClientCertificate(cert),
+	)
+
+	assert.Error(t, err)
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// newCertificate returns a self-signed client certificate and a pool containing it.
+func newCertificate(t *testing.T) (tls.Certificate, *x509.CertPool) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "client"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	leaf, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(leaf)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}, pool
+}
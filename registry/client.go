@@ -7,6 +7,8 @@ package registry
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -58,6 +60,49 @@ type Registry interface {
 
 	// IsRegisteredWithRefs determines if the schema is registered, with optional referenced schemas.
 	IsRegisteredWithRefs(ctx context.Context, subject, schema string, refs ...SchemaReference) (int, avro.Schema, error)
+
+	// DeleteSubjectPermanent permanently deletes a soft deleted subject.
+	DeleteSubjectPermanent(ctx context.Context, subject string) ([]int, error)
+
+	// DeleteVersion deletes a schema version of a subject.
+	DeleteVersion(ctx context.Context, subject string, version int) (int, error)
+
+	// DeleteVersionPermanent permanently deletes a soft deleted schema version of a subject.
+	DeleteVersionPermanent(ctx context.Context, subject string, version int) (int, error)
+
+	// GetSubjectVersionsByID gets the subject versions using the schema with the given id.
+	GetSubjectVersionsByID(ctx context.Context, id int) ([]SubjectVersion, error)
+
+	// GetReferencedBy gets the ids of the schemas referencing a subject version.
+	GetReferencedBy(ctx context.Context, subject string, version int) ([]int, error)
+
+	// CheckCompatibility checks if a schema is compatible with a subject version.
+	CheckCompatibility(
+		ctx context.Context,
+		subject string,
+		version int,
+		schema string,
+		refs ...SchemaReference,
+	) (CompatibilityResult, error)
+
+	// CheckLatestCompatibility checks if a schema is compatible with the latest subject version.
+	CheckLatestCompatibility(
+		ctx context.Context,
+		subject, schema string,
+		refs ...SchemaReference,
+	) (CompatibilityResult, error)
+
+	// GetGlobalMode gets the global mode of the registry.
+	GetGlobalMode(ctx context.Context) (string, error)
+
+	// SetGlobalMode sets the global mode of the registry.
+	SetGlobalMode(ctx context.Context, mode string) error
+
+	// GetMode gets the mode of a subject.
+	GetMode(ctx context.Context, subject string) (string, error)
+
+	// SetMode sets the mode of a subject.
+	SetMode(ctx context.Context, subject, mode string) error
 }
 
 type schemaPayload struct {
@@ -82,20 +127,10 @@ type credentials struct {
 }
 
 type schemaInfoPayload struct {
-	Schema  string `json:"schema"`
-	ID      int    `json:"id"`
-	Version int    `json:"version"`
-}
-
-// Parse converts the string schema registry response into a
-// SchemaInfo object with an avro.Schema schema.
-func (s *schemaInfoPayload) Parse() (info SchemaInfo, err error) {
-	info = SchemaInfo{
-		ID:      s.ID,
-		Version: s.Version,
-	}
-	info.Schema, err = avro.Parse(s.Schema)
-	return info, err
+	Schema     string            `json:"schema"`
+	ID         int               `json:"id"`
+	Version    int               `json:"version"`
+	References []SchemaReference `json:"references,omitempty"`
 }
 
 // SchemaInfo represents a schema and metadata information.
@@ -140,9 +175,14 @@ type Client struct {
 	client *http.Client
 	base   *url.URL
 
-	creds credentials
+	creds     credentials
+	auth      AuthProvider
+	headers   http.Header
+	tlsConfig *tls.Config
+	certs     []tls.Certificate
 
 	cache sync.Map // map[int]avro.Schema
+	refs  sync.Map // map[SchemaReference]schemaPayload
 }
 
 // NewClient creates a schema registry Client with the given base url.
@@ -156,14 +196,22 @@ func NewClient(baseURL string, opts ...ClientFunc) (*Client, error) {
 	}
 
 	c := &Client{
-		client: defaultClient,
-		base:   u,
+		client:  defaultClient,
+		base:    u,
+		headers: http.Header{},
 	}
 
 	for _, opt := range opts {
 		opt(c)
 	}
 
+	if c.tlsConfig != nil || len(c.certs) > 0 {
+		c.client, err = configureTLS(c.client, c.tlsConfig, c.certs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return c, nil
 }
 
@@ -182,7 +230,7 @@ func (c *Client) GetSchema(ctx context.Context, id int) (avro.Schema, error) {
 		return nil, err
 	}
 
-	schema, err := avro.Parse(resp.Schema)
+	schema, err := c.parseSchema(ctx, resp.Schema, resp.References)
 	if err != nil {
 		return nil, err
 	}
@@ -229,7 +277,7 @@ func (c *Client) GetSchemaByVersion(ctx context.Context, subject string, version
 	if err := c.request(ctx, http.MethodGet, p, nil, &resp); err != nil {
 		return nil, err
 	}
-	return avro.Parse(resp.Schema)
+	return c.parseSchema(ctx, resp.Schema, resp.References)
 }
 
 // GetLatestSchema gets the latest schema for a subject.
@@ -239,7 +287,7 @@ func (c *Client) GetLatestSchema(ctx context.Context, subject string) (avro.Sche
 	if err := c.request(ctx, http.MethodGet, p, nil, &resp); err != nil {
 		return nil, err
 	}
-	return avro.Parse(resp.Schema)
+	return c.parseSchema(ctx, resp.Schema, resp.References)
 }
 
 // GetSchemaInfo gets the schema and schema metadata for a subject and version.
@@ -249,7 +297,7 @@ func (c *Client) GetSchemaInfo(ctx context.Context, subject string, version int)
 	if err := c.request(ctx, http.MethodGet, p, nil, &resp); err != nil {
 		return SchemaInfo{}, err
 	}
-	return resp.Parse()
+	return c.parseSchemaInfo(ctx, resp)
 }
 
 // GetLatestSchemaInfo gets the latest schema and schema metadata for a subject.
@@ -259,7 +307,7 @@ func (c *Client) GetLatestSchemaInfo(ctx context.Context, subject string) (Schem
 	if err := c.request(ctx, http.MethodGet, p, nil, &resp); err != nil {
 		return SchemaInfo{}, err
 	}
-	return resp.Parse()
+	return c.parseSchemaInfo(ctx, resp)
 }
 
 // CreateSchema creates a schema in the registry, returning the schema id.
@@ -300,6 +348,82 @@ func (c *Client) IsRegisteredWithRefs(
 	return resp.ID, sch, err
 }
 
+func (c *Client) parseSchemaInfo(ctx context.Context, resp schemaInfoPayload) (SchemaInfo, error) {
+	schema, err := c.parseSchema(ctx, resp.Schema, resp.References)
+	return SchemaInfo{
+		Schema:  schema,
+		ID:      resp.ID,
+		Version: resp.Version,
+	}, err
+}
+
+// parseSchema parses a schema into a fresh schema cache, after parsing the
+// schemas it references into it.
+func (c *Client) parseSchema(ctx context.Context, schema string, refs []SchemaReference) (avro.Schema, error) {
+	cache := &avro.SchemaCache{}
+	if err := c.parseReferences(ctx, cache, refs, map[SchemaReference]bool{}); err != nil {
+		return nil, err
+	}
+	return avro.ParseWithCache(schema, "", cache)
+}
+
+// parseReferences parses the referenced schemas, and the schemas they reference,
+// into the cache. Seen tracks the references being parsed, which are false,
+// and the references that were parsed, which are true.
+func (c *Client) parseReferences(
+	ctx context.Context,
+	cache *avro.SchemaCache,
+	refs []SchemaReference,
+	seen map[SchemaReference]bool,
+) error {
+	for _, ref := range refs {
+		if parsed, ok := seen[ref]; ok {
+			if !parsed {
+				return fmt.Errorf("schema reference cycle at %s version %d", ref.Subject, ref.Version)
+			}
+			continue
+		}
+		seen[ref] = false
+
+		resp, err := c.getReference(ctx, ref)
+		if err != nil {
+			return err
+		}
+		if err = c.parseReferences(ctx, cache, resp.References, seen); err != nil {
+			return err
+		}
+		if _, err = avro.ParseWithCache(resp.Schema, "", cache); err != nil {
+			return fmt.Errorf("parsing reference %s: %w", ref.Name, err)
+		}
+
+		seen[ref] = true
+	}
+	return nil
+}
+
+// getReference gets a referenced schema. References to a fixed version are only fetched once.
+func (c *Client) getReference(ctx context.Context, ref SchemaReference) (schemaPayload, error) {
+	if resp, ok := c.refs.Load(ref); ok {
+		return resp.(schemaPayload), nil
+	}
+
+	version := "latest"
+	if ref.Version > 0 {
+		version = strconv.Itoa(ref.Version)
+	}
+
+	var resp schemaPayload
+	p := path.Join("subjects", ref.Subject, "versions", version)
+	if err := c.request(ctx, http.MethodGet, p, nil, &resp); err != nil {
+		return schemaPayload{}, fmt.Errorf("getting reference %s: %w", ref.Name, err)
+	}
+
+	if ref.Version > 0 {
+		c.refs.Store(ref, resp)
+	}
+	return resp, nil
+}
+
 // Compatibility levels.
 const (
 	BackwardCL           string = "BACKWARD"
@@ -321,7 +445,17 @@ func validateCompatibilityLevel(lvl string) error {
 }
 
 type compatPayload struct {
-	Compatibility string `json:"compatibility"`
+	Compatibility      string `json:"compatibility,omitempty"`
+	CompatibilityLevel string `json:"compatibilityLevel,omitempty"`
+}
+
+// level returns the compatibility level, which the registry returns
+// as "compatibilityLevel" when getting a config.
+func (p compatPayload) level() string {
+	if p.CompatibilityLevel != "" {
+		return p.CompatibilityLevel
+	}
+	return p.Compatibility
 }
 
 // SetGlobalCompatibilityLevel sets the global compatibility level of the registry.
@@ -350,7 +484,7 @@ func (c *Client) GetGlobalCompatibilityLevel(ctx context.Context) (string, error
 	if err := c.request(ctx, http.MethodGet, "config", nil, &resp); err != nil {
 		return "", err
 	}
-	return resp.Compatibility, nil
+	return resp.level(), nil
 }
 
 // GetCompatibilityLevel gets the compatibility level of a subject.
@@ -359,10 +493,181 @@ func (c *Client) GetCompatibilityLevel(ctx context.Context, subject string) (str
 	if err := c.request(ctx, http.MethodGet, path.Join("config", subject), nil, &resp); err != nil {
 		return "", err
 	}
-	return resp.Compatibility, nil
+	return resp.level(), nil
+}
+
+// DeleteSubjectPermanent permanently deletes a soft deleted subject,
+// returning the deleted versions.
+func (c *Client) DeleteSubjectPermanent(ctx context.Context, subject string) ([]int, error) {
+	var versions []int
+	p := path.Join("subjects", subject) + "?permanent=true"
+	if err := c.request(ctx, http.MethodDelete, p, nil, &versions); err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+// DeleteVersion deletes a schema version of a subject, returning the deleted version.
+func (c *Client) DeleteVersion(ctx context.Context, subject string, version int) (int, error) {
+	var deleted int
+	p := path.Join("subjects", subject, "versions", strconv.Itoa(version))
+	if err := c.request(ctx, http.MethodDelete, p, nil, &deleted); err != nil {
+		return 0, err
+	}
+	return deleted, nil
+}
+
+// DeleteVersionPermanent permanently deletes a soft deleted schema version of a subject,
+// returning the deleted version.
+func (c *Client) DeleteVersionPermanent(ctx context.Context, subject string, version int) (int, error) {
+	var deleted int
+	p := path.Join("subjects", subject, "versions", strconv.Itoa(version)) + "?permanent=true"
+	if err := c.request(ctx, http.MethodDelete, p, nil, &deleted); err != nil {
+		return 0, err
+	}
+	return deleted, nil
+}
+
+// SubjectVersion is a subject and version pair.
+type SubjectVersion struct {
+	Subject string `json:"subject"`
+	Version int    `json:"version"`
+}
+
+// GetSubjectVersionsByID gets the subject versions using the schema with the given id.
+func (c *Client) GetSubjectVersionsByID(ctx context.Context, id int) ([]SubjectVersion, error) {
+	var versions []SubjectVersion
+	p := path.Join("schemas", "ids", strconv.Itoa(id), "versions")
+	if err := c.request(ctx, http.MethodGet, p, nil, &versions); err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+// GetReferencedBy gets the ids of the schemas referencing a subject version.
+func (c *Client) GetReferencedBy(ctx context.Context, subject string, version int) ([]int, error) {
+	var ids []int
+	p := path.Join("subjects", subject, "versions", strconv.Itoa(version), "referencedby")
+	if err := c.request(ctx, http.MethodGet, p, nil, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// CompatibilityResult is the result of a compatibility check.
+type CompatibilityResult struct {
+	IsCompatible bool     `json:"is_compatible"`
+	Messages     []string `json:"messages"`
+}
+
+// CheckCompatibility checks if a schema is compatible with a subject version,
+// according to the compatibility level of the subject.
+func (c *Client) CheckCompatibility(
+	ctx context.Context,
+	subject string,
+	version int,
+	schema string,
+	references ...SchemaReference,
+) (CompatibilityResult, error) {
+	return c.checkCompatibility(ctx, subject, strconv.Itoa(version), schema, references)
+}
+
+// CheckLatestCompatibility checks if a schema is compatible with the latest subject version,
+// according to the compatibility level of the subject.
+func (c *Client) CheckLatestCompatibility(
+	ctx context.Context,
+	subject, schema string,
+	references ...SchemaReference,
+) (CompatibilityResult, error) {
+	return c.checkCompatibility(ctx, subject, "latest", schema, references)
+}
+
+func (c *Client) checkCompatibility(
+	ctx context.Context,
+	subject, version, schema string,
+	references []SchemaReference,
+) (CompatibilityResult, error) {
+	var resp CompatibilityResult
+	req := schemaPayload{Schema: schema, References: references}
+	p := path.Join("compatibility", "subjects", subject, "versions", version) + "?verbose=true"
+	if err := c.request(ctx, http.MethodPost, p, req, &resp); err != nil {
+		return CompatibilityResult{}, err
+	}
+	return resp, nil
+}
+
+// Registry modes.
+const (
+	ReadWriteMode string = "READWRITE"
+	ReadOnlyMode  string = "READONLY"
+	ImportMode    string = "IMPORT"
+)
+
+func validateMode(mode string) error {
+	switch mode {
+	case ReadWriteMode, ReadOnlyMode, ImportMode:
+		return nil
+	default:
+		return fmt.Errorf("invalid mode %s", mode)
+	}
+}
+
+type modePayload struct {
+	Mode string `json:"mode"`
+}
+
+// GetGlobalMode gets the global mode of the registry.
+func (c *Client) GetGlobalMode(ctx context.Context) (string, error) {
+	var resp modePayload
+	if err := c.request(ctx, http.MethodGet, "mode", nil, &resp); err != nil {
+		return "", err
+	}
+	return resp.Mode, nil
+}
+
+// SetGlobalMode sets the global mode of the registry.
+func (c *Client) SetGlobalMode(ctx context.Context, mode string) error {
+	if err := validateMode(mode); err != nil {
+		return err
+	}
+
+	req := modePayload{Mode: mode}
+	return c.request(ctx, http.MethodPut, "mode", req, nil)
+}
+
+// GetMode gets the mode of a subject.
+func (c *Client) GetMode(ctx context.Context, subject string) (string, error) {
+	var resp modePayload
+	if err := c.request(ctx, http.MethodGet, path.Join("mode", subject), nil, &resp); err != nil {
+		return "", err
+	}
+	return resp.Mode, nil
+}
+
+// SetMode sets the mode of a subject.
+func (c *Client) SetMode(ctx context.Context, subject, mode string) error {
+	if err := validateMode(mode); err != nil {
+		return err
+	}
+
+	req := modePayload{Mode: mode}
+	return c.request(ctx, http.MethodPut, path.Join("mode", subject), req, nil)
 }
 
 func (c *Client) request(ctx context.Context, method, path string, in, out any) error {
+	err := c.do(ctx, method, path, in, out)
+
+	var regErr Error
+	if c.auth != nil && errors.As(err, &regErr) && regErr.StatusCode == http.StatusUnauthorized {
+		if err = c.auth.Refresh(ctx); err != nil {
+			return fmt.Errorf("could not refresh authentication: %w", err)
+		}
+		return c.do(ctx, method, path, in, out)
+	}
+	return err
+}
+
+func (c *Client) do(ctx context.Context, method, path string, in, out any) error {
 	var body io.Reader
 	if in != nil {
 		b, _ := jsoniter.Marshal(in)
@@ -372,11 +677,19 @@ func (c *Client) request(ctx context.Context, method, path string, in, out any)
 	// These errors are not possible as we have already parse the base URL.
 	u, _ := c.base.Parse(path)
 	req, _ := http.NewRequestWithContext(ctx, method, u.String(), body)
+	for k, v := range c.headers {
+		req.Header[k] = append([]string(nil), v...)
+	}
 	req.Header.Set("Content-Type", contentType)
 
 	if len(c.creds.username) > 0 || len(c.creds.password) > 0 {
 		req.SetBasicAuth(c.creds.username, c.creds.password)
 	}
+	if c.auth != nil {
+		if err := c.auth.Authenticate(req); err != nil {
+			return fmt.Errorf("could not authenticate request: %w", err)
+		}
+	}
 
 	resp, err := c.client.Do(req)
 	if err != nil {
@@ -399,6 +712,26 @@ func (c *Client) request(ctx context.Context, method, path string, in, out any)
 	return nil
 }
 
+// Registry error codes.
+const (
+	CodeSubjectNotFound            = 40401
+	CodeVersionNotFound            = 40402
+	CodeSchemaNotFound             = 40403
+	CodeSubjectSoftDeleted         = 40404
+	CodeSubjectNotSoftDeleted      = 40405
+	CodeVersionSoftDeleted         = 40406
+	CodeVersionNotSoftDeleted      = 40407
+	CodeSubjectCompatNotConfigured = 40408
+	CodeSubjectModeNotConfigured   = 40409
+	CodeIncompatibleSchema         = 409
+	CodeInvalidSchema              = 42201
+	CodeInvalidVersion             = 42202
+	CodeInvalidCompatibilityLevel  = 42203
+	CodeInvalidMode                = 42204
+	CodeOperationNotPermitted      = 42205
+	CodeReferenceExists            = 42206
+)
+
 // Error is returned by the registry when there is an error.
 type Error struct {
 	StatusCode int    `json:"-"`
@@ -413,3 +746,9 @@ func (e Error) Error() string {
 	}
 	return "registry error: " + strconv.Itoa(e.StatusCode)
 }
+
+// HasCode determines if err is a registry Error with the given error code.
+func HasCode(err error, code int) bool {
+	var regErr Error
+	return errors.As(err, &regErr) && regErr.Code == code
+}
@@ -3,11 +3,14 @@ package registry_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/kjuulh/avro/v2"
 	"github.com/kjuulh/avro/v2/registry"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -131,6 +134,136 @@ func TestClient_GetSchemaSchemaError(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestClient_GetSchemaWithReferences(t *testing.T) {
+	calls := map[string]int{}
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		calls[r.URL.Path]++
+
+		switch r.URL.Path {
+		case "/schemas/ids/5":
+			_, _ = w.Write([]byte(`{"schema":"{\"type\":\"record\",\"name\":\"Outer\",\"namespace\":\"org.hamba.avro\",\"fields\":[{\"name\":\"inner\",\"type\":\"Inner\"},{\"name\":\"kind\",\"type\":\"Kind\"}]}",` +
+				`"references":[{"name":"org.hamba.avro.Inner","subject":"inner","version":1},{"name":"org.hamba.avro.Kind","subject":"kind","version":2}]}`))
+		case "/schemas/ids/6":
+			_, _ = w.Write([]byte(`{"schema":"[\"null\",\"org.hamba.avro.Kind\"]",` +
+				`"references":[{"name":"org.hamba.avro.Kind","subject":"kind","version":2}]}`))
+		case "/subjects/inner/versions/1":
+			_, _ = w.Write([]byte(`{"schema":"{\"type\":\"record\",\"name\":\"Inner\",\"namespace\":\"org.hamba.avro\",\"fields\":[{\"name\":\"kind\",\"type\":\"Kind\"}]}",` +
+				`"references":[{"name":"org.hamba.avro.Kind","subject":"kind","version":2}]}`))
+		case "/subjects/kind/versions/2":
+			_, _ = w.Write([]byte(`{"schema":"{\"type\":\"enum\",\"name\":\"Kind\",\"namespace\":\"org.hamba.avro\",\"symbols\":[\"A\",\"B\"]}"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(s.Close)
+	client, _ := registry.NewClient(s.URL)
+
+	schema, err := client.GetSchema(context.Background(), 5)
+	require.NoError(t, err)
+	other, err := client.GetSchema(context.Background(), 6)
+	require.NoError(t, err)
+
+	rec := schema.(*avro.RecordSchema)
+	assert.Equal(t, "org.hamba.avro.Inner", rec.Fields()[0].Type().(avro.NamedSchema).FullName())
+	assert.Equal(t, avro.Enum, rec.Fields()[1].Type().(*avro.RefSchema).Schema().Type())
+	assert.Equal(t, `["null",{"name":"org.hamba.avro.Kind","type":"enum","symbols":["A","B"]}]`, other.String())
+	assert.Equal(t, 1, calls["/subjects/inner/versions/1"])
+	assert.Equal(t, 1, calls["/subjects/kind/versions/2"])
+}
+
+func TestClient_GetSchemaWithReferenceVersions(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/schemas/ids/1":
+			_, _ = w.Write([]byte(`{"schema":"{\"type\":\"record\",\"name\":\"A\",\"fields\":[{\"name\":\"foo\",\"type\":\"Foo\"}]}",` +
+				`"references":[{"name":"Foo","subject":"foo","version":1}]}`))
+		case "/schemas/ids/2":
+			_, _ = w.Write([]byte(`{"schema":"{\"type\":\"record\",\"name\":\"B\",\"fields\":[{\"name\":\"foo\",\"type\":\"Foo\"}]}",` +
+				`"references":[{"name":"Foo","subject":"foo","version":2}]}`))
+		case "/schemas/ids/3":
+			_, _ = w.Write([]byte(`{"schema":"{\"type\":\"record\",\"name\":\"C\",\"fields\":[{\"name\":\"foo\",\"type\":\"Foo\"}]}",` +
+				`"references":[{"name":"Foo","subject":"foo","version":1}]}`))
+		case "/subjects/foo/versions/1":
+			_, _ = w.Write([]byte(`{"schema":"{\"type\":\"record\",\"name\":\"Foo\",\"fields\":[{\"name\":\"x\",\"type\":\"int\"}]}"}`))
+		case "/subjects/foo/versions/2":
+			_, _ = w.Write([]byte(`{"schema":"{\"type\":\"record\",\"name\":\"Foo\",\"fields\":[{\"name\":\"y\",\"type\":\"string\"}]}"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(s.Close)
+	client, _ := registry.NewClient(s.URL)
+
+	var fields []string
+	for _, id := range []int{1, 2, 3} {
+		schema, err := client.GetSchema(context.Background(), id)
+		require.NoError(t, err)
+		foo := schema.(*avro.RecordSchema).Fields()[0].Type().(*avro.RecordSchema)
+		fields = append(fields, foo.Fields()[0].Name())
+	}
+
+	assert.Equal(t, []string{"x", "y", "x"}, fields)
+	assert.Nil(t, avro.DefaultSchemaCache.Get("Foo"))
+}
+
+func TestClient_GetSchemaWithLatestReference(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/subjects/test/versions/latest":
+			_, _ = w.Write([]byte(`{"schema":"{\"type\":\"fixed\",\"name\":\"Hash\",\"size\":16}"}`))
+		default:
+			_, _ = w.Write([]byte(`{"schema":"{\"type\":\"array\",\"items\":\"Hash\"}",` +
+				`"references":[{"name":"Hash","subject":"test","version":-1}]}`))
+		}
+	}))
+	t.Cleanup(s.Close)
+	client, _ := registry.NewClient(s.URL)
+
+	schema, err := client.GetLatestSchema(context.Background(), "foobar")
+
+	require.NoError(t, err)
+	assert.Equal(t, `{"type":"array","items":{"name":"Hash","type":"fixed","size":16}}`, schema.String())
+}
+
+func TestClient_GetSchemaWithReferenceCycle(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/subjects/a/versions/1":
+			_, _ = w.Write([]byte(`{"schema":"\"string\"","references":[{"name":"B","subject":"b","version":1}]}`))
+		case "/subjects/b/versions/1":
+			_, _ = w.Write([]byte(`{"schema":"\"string\"","references":[{"name":"A","subject":"a","version":1}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"schema":"\"string\"","references":[{"name":"A","subject":"a","version":1}]}`))
+		}
+	}))
+	t.Cleanup(s.Close)
+	client, _ := registry.NewClient(s.URL)
+
+	_, err := client.GetSchema(context.Background(), 5)
+
+	assert.ErrorContains(t, err, "cycle")
+}
+
+func TestClient_GetSchemaWithReferenceError(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/schemas/ids/5" {
+			_, _ = w.Write([]byte(`{"schema":"\"Missing\"","references":[{"name":"Missing","subject":"missing","version":1}]}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error_code":40401,"message":"Subject 'missing' not found."}`))
+	}))
+	t.Cleanup(s.Close)
+	client, _ := registry.NewClient(s.URL)
+
+	_, err := client.GetSchema(context.Background(), 5)
+
+	var regErr registry.Error
+	require.ErrorAs(t, err, &regErr)
+	assert.Equal(t, 40401, regErr.Code)
+}
+
 func TestClient_GetSubjects(t *testing.T) {
 	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "GET", r.Method)
@@ -621,6 +754,22 @@ func TestClient_GetGlobalCompatibilityLevel(t *testing.T) {
 	assert.Equal(t, registry.FullCL, compatibilityLevel)
 }
 
+func TestClient_GetGlobalCompatibilityLevelFromCompatibilityLevel(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/config", r.URL.Path)
+
+		_, _ = w.Write([]byte(`{"compatibilityLevel":"FULL"}`))
+	}))
+	t.Cleanup(s.Close)
+	client, _ := registry.NewClient(s.URL)
+
+	compatibilityLevel, err := client.GetGlobalCompatibilityLevel(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, registry.FullCL, compatibilityLevel)
+}
+
 func TestClient_GetGlobalCompatibilityLevelError(t *testing.T) {
 	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(500)
@@ -650,6 +799,23 @@ func TestClient_GetCompatibilityLevel(t *testing.T) {
 	assert.Equal(t, registry.FullCL, compatibilityLevel)
 }
 
+func TestClient_GetCompatibilityLevelFromCompatibilityLevel(t *testing.T) {
+	subject := "boh_subj"
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/config/"+subject, r.URL.Path)
+
+		_, _ = w.Write([]byte(`{"compatibilityLevel":"FULL"}`))
+	}))
+	t.Cleanup(s.Close)
+	client, _ := registry.NewClient(s.URL)
+
+	compatibilityLevel, err := client.GetCompatibilityLevel(context.Background(), subject)
+
+	require.NoError(t, err)
+	assert.Equal(t, registry.FullCL, compatibilityLevel)
+}
+
 func TestClient_GetCompatibilityLevelError(t *testing.T) {
 	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(500)
@@ -741,6 +907,293 @@ func TestClient_SetCompatibilityLevelHandlesInvalidLevel(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestClient_DeleteSubjectPermanent(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "/subjects/foobar", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("permanent"))
+
+		_, _ = w.Write([]byte(`[1,2]`))
+	}))
+	t.Cleanup(s.Close)
+	client, _ := registry.NewClient(s.URL)
+
+	versions, err := client.DeleteSubjectPermanent(context.Background(), "foobar")
+
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, versions)
+}
+
+func TestClient_DeleteSubjectPermanentError(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error_code":40405,"message":"Subject 'foobar' was not deleted first before being permanently deleted"}`))
+	}))
+	t.Cleanup(s.Close)
+	client, _ := registry.NewClient(s.URL)
+
+	_, err := client.DeleteSubjectPermanent(context.Background(), "foobar")
+
+	assert.True(t, registry.HasCode(err, registry.CodeSubjectNotSoftDeleted))
+}
+
+func TestClient_DeleteVersion(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "/subjects/foobar/versions/2", r.URL.Path)
+		assert.Empty(t, r.URL.Query().Get("permanent"))
+
+		_, _ = w.Write([]byte(`2`))
+	}))
+	t.Cleanup(s.Close)
+	client, _ := registry.NewClient(s.URL)
+
+	version, err := client.DeleteVersion(context.Background(), "foobar", 2)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, version)
+}
+
+func TestClient_DeleteVersionPermanent(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "/subjects/foobar/versions/2", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("permanent"))
+
+		_, _ = w.Write([]byte(`2`))
+	}))
+	t.Cleanup(s.Close)
+	client, _ := registry.NewClient(s.URL)
+
+	version, err := client.DeleteVersionPermanent(context.Background(), "foobar", 2)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, version)
+}
+
+func TestClient_DeleteVersionError(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error_code":40402,"message":"Version 2 not found."}`))
+	}))
+	t.Cleanup(s.Close)
+	client, _ := registry.NewClient(s.URL)
+
+	_, err := client.DeleteVersion(context.Background(), "foobar", 2)
+
+	assert.True(t, registry.HasCode(err, registry.CodeVersionNotFound))
+}
+
+func TestClient_GetSubjectVersionsByID(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/schemas/ids/5/versions", r.URL.Path)
+
+		_, _ = w.Write([]byte(`[{"subject":"foo","version":1},{"subject":"bar","version":3}]`))
+	}))
+	t.Cleanup(s.Close)
+	client, _ := registry.NewClient(s.URL)
+
+	versions, err := client.GetSubjectVersionsByID(context.Background(), 5)
+
+	require.NoError(t, err)
+	assert.Equal(t, []registry.SubjectVersion{{Subject: "foo", Version: 1}, {Subject: "bar", Version: 3}}, versions)
+}
+
+func TestClient_GetSubjectVersionsByIDError(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error_code":40403,"message":"Schema 5 not found"}`))
+	}))
+	t.Cleanup(s.Close)
+	client, _ := registry.NewClient(s.URL)
+
+	_, err := client.GetSubjectVersionsByID(context.Background(), 5)
+
+	assert.True(t, registry.HasCode(err, registry.CodeSchemaNotFound))
+}
+
+func TestClient_GetReferencedBy(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/subjects/foobar/versions/1/referencedby", r.URL.Path)
+
+		_, _ = w.Write([]byte(`[4,7]`))
+	}))
+	t.Cleanup(s.Close)
+	client, _ := registry.NewClient(s.URL)
+
+	ids, err := client.GetReferencedBy(context.Background(), "foobar", 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, []int{4, 7}, ids)
+}
+
+func TestClient_GetReferencedByError(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(500)
+	}))
+	t.Cleanup(s.Close)
+	client, _ := registry.NewClient(s.URL)
+
+	_, err := client.GetReferencedBy(context.Background(), "foobar", 1)
+
+	assert.Error(t, err)
+}
+
+func TestClient_CheckCompatibility(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/compatibility/subjects/foobar/versions/3", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("verbose"))
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, `{"schema":"[\"null\",\"int\"]"}`, string(body))
+
+		_, _ = w.Write([]byte(`{"is_compatible":false,"messages":["reader union lacking writer type: STRING"]}`))
+	}))
+	t.Cleanup(s.Close)
+	client, _ := registry.NewClient(s.URL)
+
+	res, err := client.CheckCompatibility(context.Background(), "foobar", 3, `["null","int"]`)
+
+	require.NoError(t, err)
+	assert.False(t, res.IsCompatible)
+	assert.Equal(t, []string{"reader union lacking writer type: STRING"}, res.Messages)
+}
+
+func TestClient_CheckLatestCompatibility(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/compatibility/subjects/foobar/versions/latest", r.URL.Path)
+
+		_, _ = w.Write([]byte(`{"is_compatible":true}`))
+	}))
+	t.Cleanup(s.Close)
+	client, _ := registry.NewClient(s.URL)
+
+	res, err := client.CheckLatestCompatibility(context.Background(), "foobar", `["null","int"]`)
+
+	require.NoError(t, err)
+	assert.True(t, res.IsCompatible)
+}
+
+func TestClient_CheckCompatibilityError(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"error_code":42201,"message":"Invalid schema"}`))
+	}))
+	t.Cleanup(s.Close)
+	client, _ := registry.NewClient(s.URL)
+
+	_, err := client.CheckLatestCompatibility(context.Background(), "foobar", `{}`)
+
+	assert.True(t, registry.HasCode(err, registry.CodeInvalidSchema))
+}
+
+func TestClient_GetGlobalMode(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/mode", r.URL.Path)
+
+		_, _ = w.Write([]byte(`{"mode":"READONLY"}`))
+	}))
+	t.Cleanup(s.Close)
+	client, _ := registry.NewClient(s.URL)
+
+	mode, err := client.GetGlobalMode(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, registry.ReadOnlyMode, mode)
+}
+
+func TestClient_GetMode(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/mode/foobar", r.URL.Path)
+
+		_, _ = w.Write([]byte(`{"mode":"IMPORT"}`))
+	}))
+	t.Cleanup(s.Close)
+	client, _ := registry.NewClient(s.URL)
+
+	mode, err := client.GetMode(context.Background(), "foobar")
+
+	require.NoError(t, err)
+	assert.Equal(t, registry.ImportMode, mode)
+}
+
+func TestClient_GetModeError(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error_code":40409,"message":"Subject 'foobar' does not have subject-level mode configured"}`))
+	}))
+	t.Cleanup(s.Close)
+	client, _ := registry.NewClient(s.URL)
+
+	_, err := client.GetMode(context.Background(), "foobar")
+
+	assert.True(t, registry.HasCode(err, registry.CodeSubjectModeNotConfigured))
+}
+
+func TestClient_SetGlobalMode(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/mode", r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, `{"mode":"READONLY"}`, string(body))
+
+		_, _ = w.Write([]byte(`{"mode":"READONLY"}`))
+	}))
+	t.Cleanup(s.Close)
+	client, _ := registry.NewClient(s.URL)
+
+	err := client.SetGlobalMode(context.Background(), registry.ReadOnlyMode)
+
+	assert.NoError(t, err)
+}
+
+func TestClient_SetMode(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/mode/foobar", r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, `{"mode":"IMPORT"}`, string(body))
+
+		_, _ = w.Write([]byte(`{"mode":"IMPORT"}`))
+	}))
+	t.Cleanup(s.Close)
+	client, _ := registry.NewClient(s.URL)
+
+	err := client.SetMode(context.Background(), "foobar", registry.ImportMode)
+
+	assert.NoError(t, err)
+}
+
+func TestClient_SetModeError(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"error_code":42205,"message":"Cannot import since found existing subjects"}`))
+	}))
+	t.Cleanup(s.Close)
+	client, _ := registry.NewClient(s.URL)
+
+	err := client.SetMode(context.Background(), "foobar", registry.ImportMode)
+
+	assert.True(t, registry.HasCode(err, registry.CodeOperationNotPermitted))
+}
+
+func TestClient_SetModeHandlesInvalidMode(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.FailNow(t, "unexpected call")
+	}))
+	t.Cleanup(s.Close)
+	client, _ := registry.NewClient(s.URL)
+
+	err := client.SetMode(context.Background(), "foobar", "WRITEONLY")
+
+	assert.Error(t, err)
+}
+
 func TestClient_HandlesServerError(t *testing.T) {
 	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
 	s.Close()
@@ -772,3 +1225,11 @@ func TestError_ErrorEmptyMessage(t *testing.T) {
 
 	assert.Equal(t, "registry error: 404", str)
 }
+
+func TestHasCode(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", registry.Error{StatusCode: 404, Code: registry.CodeSubjectNotFound})
+
+	assert.True(t, registry.HasCode(err, registry.CodeSubjectNotFound))
+	assert.False(t, registry.HasCode(err, registry.CodeVersionNotFound))
+	assert.False(t, registry.HasCode(errors.New("test"), registry.CodeSubjectNotFound))
+}
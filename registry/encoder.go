@@ -0,0 +1,169 @@
+package registry
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/kjuulh/avro/v2"
+)
+
+// SubjectNameStrategy returns the subject a schema is registered under
+// for a topic, and whether the schema is of the record key or value.
+type SubjectNameStrategy func(topic string, isKey bool, schema avro.Schema) (string, error)
+
+// TopicNameStrategy returns the subject "<topic>-key" or "<topic>-value".
+func TopicNameStrategy(topic string, isKey bool, _ avro.Schema) (string, error) {
+	if topic == "" {
+		return "", errors.New("topic cannot be empty")
+	}
+	if isKey {
+		return topic + "-key", nil
+	}
+	return topic + "-value", nil
+}
+
+// RecordNameStrategy returns the full name of the schema as the subject.
+// The schema must be a named schema.
+func RecordNameStrategy(_ string, _ bool, schema avro.Schema) (string, error) {
+	named, ok := schema.(avro.NamedSchema)
+	if !ok {
+		return "", fmt.Errorf("schema %s is not a named schema", schema.Type())
+	}
+	return named.FullName(), nil
+}
+
+// TopicRecordNameStrategy returns the subject "<topic>-<full name>".
+// The schema must be a named schema.
+func TopicRecordNameStrategy(topic string, isKey bool, schema avro.Schema) (string, error) {
+	if topic == "" {
+		return "", errors.New("topic cannot be empty")
+	}
+	name, err := RecordNameStrategy(topic, isKey, schema)
+	if err != nil {
+		return "", err
+	}
+	return topic + "-" + name, nil
+}
+
+// EncoderFunc is a function used to customize the Encoder.
+type EncoderFunc func(*Encoder)
+
+// WithEncoderAPI sets the avro configuration on the encoder.
+func WithEncoderAPI(api avro.API) EncoderFunc {
+	return func(e *Encoder) {
+		e.api = api
+	}
+}
+
+// WithSubjectNameStrategy sets the strategy used to get the subject of a schema.
+// By default, TopicNameStrategy is used.
+func WithSubjectNameStrategy(strategy SubjectNameStrategy) EncoderFunc {
+	return func(e *Encoder) {
+		e.strategy = strategy
+	}
+}
+
+// WithAutoRegister sets whether schemas are registered by the encoder.
+// If false, schemas must already be registered under their subject.
+// By default, schemas are registered.
+func WithAutoRegister(register bool) EncoderFunc {
+	return func(e *Encoder) {
+		e.autoRegister = register
+	}
+}
+
+// WithKey sets the encoder to encode record keys, using key subjects.
+func WithKey() EncoderFunc {
+	return func(e *Encoder) {
+		e.isKey = true
+	}
+}
+
+type encoderCacheKey struct {
+	subject     string
+	fingerprint [32]byte
+}
+
+// Encoder encodes avro payloads in the confluent wire format.
+type Encoder struct {
+	client       *Client
+	api          avro.API
+	strategy     SubjectNameStrategy
+	autoRegister bool
+	isKey        bool
+
+	ids sync.Map // map[encoderCacheKey]int
+}
+
+// NewEncoder returns an encoder that will register or look up schemas with client.
+func NewEncoder(client *Client, opts ...EncoderFunc) *Encoder {
+	e := &Encoder{
+		client:       client,
+		api:          avro.DefaultConfig,
+		strategy:     TopicNameStrategy,
+		autoRegister: true,
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// Encode encodes v with schema for topic, in the Confluent wire format.
+//
+// The schema id is registered or looked up under the subject returned by
+// the subject name strategy, and cached in memory after it is found.
+// See:
+// https://docs.confluent.io/3.2.0/schema-registry/docs/serializer-formatter.html#wire-format.
+func (e *Encoder) Encode(ctx context.Context, topic string, schema avro.Schema, v any) ([]byte, error) {
+	id, err := e.SchemaID(ctx, topic, schema)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := e.api.Marshal(schema, v)
+	if err != nil {
+		return nil, err
+	}
+
+	return frame(id, data), nil
+}
+
+// SchemaID returns the id of schema under the subject for topic.
+func (e *Encoder) SchemaID(ctx context.Context, topic string, schema avro.Schema) (int, error) {
+	subject, err := e.strategy(topic, e.isKey, schema)
+	if err != nil {
+		return 0, fmt.Errorf("getting subject: %w", err)
+	}
+
+	key := encoderCacheKey{subject: subject, fingerprint: schema.Fingerprint()}
+	if id, ok := e.ids.Load(key); ok {
+		return id.(int), nil
+	}
+
+	var id int
+	if e.autoRegister {
+		id, _, err = e.client.CreateSchema(ctx, subject, schema.String())
+		if err != nil {
+			return 0, fmt.Errorf("registering schema: %w", err)
+		}
+	} else {
+		id, _, err = e.client.IsRegistered(ctx, subject, schema.String())
+		if err != nil {
+			return 0, fmt.Errorf("looking up schema: %w", err)
+		}
+	}
+
+	e.ids.Store(key, id)
+	return id, nil
+}
+
+func frame(id int, data []byte) []byte {
+	b := make([]byte, 5+len(data))
+	binary.BigEndian.PutUint32(b[1:5], uint32(id))
+	copy(b[5:], data)
+	return b
+}
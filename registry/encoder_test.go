@@ -0,0 +1,191 @@
+package registry_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kjuulh/avro/v2"
+	"github.com/kjuulh/avro/v2/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncoder_Encode(t *testing.T) {
+	var calls int
+	h := http.NewServeMux()
+	h.Handle("/subjects/test-value/versions", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "POST", req.Method)
+		var payload map[string]any
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&payload))
+		assert.Equal(t, `"int"`, payload["schema"])
+		calls++
+
+		_, _ = rw.Write([]byte(`{"id":42}`))
+	}))
+	h.Handle("/schemas/ids/42", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`{"schema":"int"}`))
+	}))
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	client, _ := registry.NewClient(srv.URL)
+	encoder := registry.NewEncoder(client)
+	schema := avro.MustParse(`"int"`)
+
+	got, err := encoder.Encode(context.Background(), "test", schema, 128)
+	require.NoError(t, err)
+	_, err = encoder.Encode(context.Background(), "test", schema, 128)
+	require.NoError(t, err)
+
+	assert.Equal(t, []byte{0x0, 0x0, 0x0, 0x0, 0x2a, 0x80, 0x2}, got)
+	assert.Equal(t, 1, calls)
+
+	var v int
+	err = registry.NewDecoder(client).Decode(context.Background(), got, &v)
+	require.NoError(t, err)
+	assert.Equal(t, 128, v)
+}
+
+func TestEncoder_EncodeWithoutAutoRegister(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/subjects/test-key", r.URL.Path)
+
+		_, _ = w.Write([]byte(`{"id":258}`))
+	}))
+	t.Cleanup(s.Close)
+	client, _ := registry.NewClient(s.URL)
+	encoder := registry.NewEncoder(client, registry.WithAutoRegister(false), registry.WithKey())
+
+	got, err := encoder.Encode(context.Background(), "test", avro.MustParse(`"string"`), "foo")
+
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x0, 0x0, 0x0, 0x1, 0x2, 0x6, 'f', 'o', 'o'}, got)
+}
+
+func TestEncoder_EncodeWithSubjectNameStrategy(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/subjects/test-org.hamba.avro.Test/versions", r.URL.Path)
+
+		_, _ = w.Write([]byte(`{"id":1}`))
+	}))
+	t.Cleanup(s.Close)
+	client, _ := registry.NewClient(s.URL)
+	encoder := registry.NewEncoder(client, registry.WithSubjectNameStrategy(registry.TopicRecordNameStrategy))
+	schema := avro.MustParse(`{"type":"record","name":"Test","namespace":"org.hamba.avro","fields":[{"name":"a","type":"long"}]}`)
+
+	got, err := encoder.Encode(context.Background(), "test", schema, map[string]any{"a": int64(27)})
+
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x0, 0x0, 0x0, 0x0, 0x1, 0x36}, got)
+}
+
+func TestEncoder_EncodeErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   []registry.EncoderFunc
+		topic  string
+		schema string
+		value  any
+	}{
+		{
+			name:   "handles empty topic",
+			schema: `"int"`,
+			value:  1,
+		},
+		{
+			name:   "handles unnamed schema with record strategy",
+			opts:   []registry.EncoderFunc{registry.WithSubjectNameStrategy(registry.RecordNameStrategy)},
+			topic:  "test",
+			schema: `"int"`,
+			value:  1,
+		},
+		{
+			name:   "handles registry error",
+			topic:  "error",
+			schema: `"int"`,
+			value:  1,
+		},
+		{
+			name:   "handles lookup error",
+			opts:   []registry.EncoderFunc{registry.WithAutoRegister(false)},
+			topic:  "error",
+			schema: `"int"`,
+			value:  1,
+		},
+		{
+			name:   "handles marshal error",
+			topic:  "test",
+			schema: `"int"`,
+			value:  "foo",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/subjects/test-value/versions" {
+					_, _ = w.Write([]byte(`{"id":1}`))
+					return
+				}
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"error_code":40401,"message":"Subject not found"}`))
+			}))
+			t.Cleanup(s.Close)
+			client, _ := registry.NewClient(s.URL)
+			encoder := registry.NewEncoder(client, test.opts...)
+
+			_, err := encoder.Encode(context.Background(), test.topic, avro.MustParse(test.schema), test.value)
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestSubjectNameStrategies(t *testing.T) {
+	schema := avro.MustParse(`{"type":"record","name":"Test","namespace":"org.hamba.avro","fields":[{"name":"a","type":"long"}]}`)
+
+	tests := []struct {
+		name     string
+		strategy registry.SubjectNameStrategy
+		isKey    bool
+		want     string
+	}{
+		{
+			name:     "topic value",
+			strategy: registry.TopicNameStrategy,
+			want:     "test-value",
+		},
+		{
+			name:     "topic key",
+			strategy: registry.TopicNameStrategy,
+			isKey:    true,
+			want:     "test-key",
+		},
+		{
+			name:     "record",
+			strategy: registry.RecordNameStrategy,
+			want:     "org.hamba.avro.Test",
+		},
+		{
+			name:     "topic record",
+			strategy: registry.TopicRecordNameStrategy,
+			isKey:    true,
+			want:     "test-org.hamba.avro.Test",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			got, err := test.strategy("test", test.isKey, schema)
+
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
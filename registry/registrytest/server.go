@@ -0,0 +1,758 @@
+// Package registrytest implements an in-memory schema registry for testing.
+//
+// The Server implements the subset of the Confluent schema registry API used by
+// registry.Client, and is intended to be started with httptest.NewServer.
+package registrytest
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/kjuulh/avro/v2"
+	"github.com/kjuulh/avro/v2/registry"
+)
+
+const contentType = "application/vnd.schemaregistry.v1+json"
+
+type schemaEntry struct {
+	id     int
+	schema string
+	refs   []registry.SchemaReference
+	parsed avro.Schema
+}
+
+type version struct {
+	version int
+	id      int
+	deleted bool
+}
+
+// Server is an in-memory schema registry.
+type Server struct {
+	compat *avro.SchemaCompatibility
+
+	mu            sync.Mutex
+	schemas       map[int]*schemaEntry
+	subjects      map[string][]*version
+	compatLevel   string
+	subjectCompat map[string]string
+	mode          string
+	subjectMode   map[string]string
+}
+
+// NewServer returns a new empty in-memory schema registry,
+// with a global compatibility level of BACKWARD and a global mode of READWRITE.
+func NewServer() *Server {
+	return &Server{
+		compat:        avro.NewSchemaCompatibility(),
+		schemas:       map[int]*schemaEntry{},
+		subjects:      map[string][]*version{},
+		compatLevel:   registry.BackwardCL,
+		subjectCompat: map[string]string{},
+		mode:          registry.ReadWriteMode,
+		subjectMode:   map[string]string{},
+	}
+}
+
+// ServeHTTP serves the schema registry API.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var (
+		out any
+		err *apiError
+	)
+	deleted := r.URL.Query().Get("deleted") == "true"
+	permanent := r.URL.Query().Get("permanent") == "true"
+	defaultToGlobal := r.URL.Query().Get("defaultToGlobal") == "true"
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch route(r.Method, parts) {
+	case "GET /subjects":
+		out = s.getSubjects(deleted)
+	case "POST /subjects/*":
+		out, err = s.lookupSchema(r, parts[1])
+	case "DELETE /subjects/*":
+		out, err = s.deleteSubject(parts[1], permanent)
+	case "GET /subjects/*/versions":
+		out, err = s.getVersions(parts[1], deleted)
+	case "POST /subjects/*/versions":
+		out, err = s.registerSchema(r, parts[1])
+	case "GET /subjects/*/versions/*":
+		out, err = s.getVersion(parts[1], parts[3], deleted)
+	case "DELETE /subjects/*/versions/*":
+		out, err = s.deleteVersion(parts[1], parts[3], permanent)
+	case "GET /subjects/*/versions/*/referencedby":
+		out, err = s.getReferencedBy(parts[1], parts[3])
+	case "GET /schemas/ids/*":
+		out, err = s.getSchemaByID(parts[2])
+	case "GET /schemas/ids/*/versions":
+		out, err = s.getSubjectVersionsByID(parts[2])
+	case "POST /compatibility/subjects/*/versions/*":
+		out, err = s.testCompatibility(r, parts[2], parts[4])
+	case "GET /config":
+		out = compatLevelPayload{CompatibilityLevel: s.compatLevel}
+	case "PUT /config":
+		out, err = s.setCompatibilityLevel(r, "")
+	case "GET /config/*":
+		out, err = s.getCompatibilityLevel(parts[1], defaultToGlobal)
+	case "PUT /config/*":
+		out, err = s.setCompatibilityLevel(r, parts[1])
+	case "DELETE /config/*":
+		out, err = s.deleteCompatibilityLevel(parts[1])
+	case "GET /mode":
+		out = modePayload{Mode: s.mode}
+	case "PUT /mode":
+		out, err = s.setMode(r, "")
+	case "GET /mode/*":
+		out, err = s.getMode(parts[1], defaultToGlobal)
+	case "PUT /mode/*":
+		out, err = s.setMode(r, parts[1])
+	case "DELETE /mode/*":
+		out, err = s.deleteMode(parts[1])
+	default:
+		err = &apiError{status: http.StatusNotFound, Code: http.StatusNotFound, Message: "HTTP 404 Not Found"}
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	if err != nil {
+		w.WriteHeader(err.status)
+		out = err
+	}
+	_ = jsoniter.NewEncoder(w).Encode(out)
+}
+
+// route returns the route pattern of a request, with path parameters replaced by "*".
+func route(method string, parts []string) string {
+	pattern := make([]string, len(parts))
+	for i, p := range parts {
+		pattern[i] = p
+		switch {
+		case i == 1 && (parts[0] == "subjects" || parts[0] == "config" || parts[0] == "mode"):
+			pattern[i] = "*"
+		case i == 2 && parts[0] == "schemas" && parts[1] == "ids":
+			pattern[i] = "*"
+		case i == 2 && parts[0] == "compatibility" && parts[1] == "subjects":
+			pattern[i] = "*"
+		case i == 3 && parts[0] == "subjects" && parts[2] == "versions":
+			pattern[i] = "*"
+		case i == 4 && parts[0] == "compatibility" && parts[3] == "versions":
+			pattern[i] = "*"
+		}
+	}
+	return method + " /" + strings.Join(pattern, "/")
+}
+
+type apiError struct {
+	status  int
+	Code    int    `json:"error_code"`
+	Message string `json:"message"`
+}
+
+func errorf(code int, format string, args ...any) *apiError {
+	status := code
+	if status > 999 {
+		status /= 100
+	}
+	return &apiError{status: status, Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+type schemaPayload struct {
+	Schema     string                     `json:"schema"`
+	SchemaType string                     `json:"schemaType,omitempty"`
+	References []registry.SchemaReference `json:"references,omitempty"`
+	ID         int                        `json:"id,omitempty"`
+	Version    int                        `json:"version,omitempty"`
+}
+
+type idPayload struct {
+	ID int `json:"id"`
+}
+
+type versionPayload struct {
+	Subject    string                     `json:"subject"`
+	ID         int                        `json:"id"`
+	Version    int                        `json:"version"`
+	Schema     string                     `json:"schema"`
+	References []registry.SchemaReference `json:"references,omitempty"`
+}
+
+type compatPayload struct {
+	Compatibility string `json:"compatibility"`
+}
+
+type compatLevelPayload struct {
+	CompatibilityLevel string `json:"compatibilityLevel"`
+}
+
+type modePayload struct {
+	Mode string `json:"mode"`
+}
+
+func (s *Server) getSubjects(deleted bool) []string {
+	subjects := []string{}
+	for subject := range s.subjects {
+		if len(s.liveVersions(subject, deleted)) > 0 {
+			subjects = append(subjects, subject)
+		}
+	}
+	sort.Strings(subjects)
+	return subjects
+}
+
+func (s *Server) getVersions(subject string, deleted bool) ([]int, *apiError) {
+	versions := s.liveVersions(subject, deleted)
+	if len(versions) == 0 {
+		return nil, errorf(registry.CodeSubjectNotFound, "Subject '%s' not found.", subject)
+	}
+
+	nums := make([]int, len(versions))
+	for i, v := range versions {
+		nums[i] = v.version
+	}
+	return nums, nil
+}
+
+func (s *Server) getVersion(subject, ver string, deleted bool) (any, *apiError) {
+	v, err := s.findVersion(subject, ver, deleted)
+	if err != nil {
+		return nil, err
+	}
+	return s.versionPayload(subject, v), nil
+}
+
+func (s *Server) getSchemaByID(id string) (any, *apiError) {
+	n, _ := strconv.Atoi(id)
+	entry := s.schemaByID(n)
+	if entry == nil {
+		return nil, errorf(registry.CodeSchemaNotFound, "Schema %s not found", id)
+	}
+	return schemaPayload{Schema: entry.schema, References: entry.refs}, nil
+}
+
+func (s *Server) getSubjectVersionsByID(id string) (any, *apiError) {
+	n, _ := strconv.Atoi(id)
+	if s.schemaByID(n) == nil {
+		return nil, errorf(registry.CodeSchemaNotFound, "Schema %s not found", id)
+	}
+
+	subjects := make([]string, 0, len(s.subjects))
+	for subject := range s.subjects {
+		subjects = append(subjects, subject)
+	}
+	sort.Strings(subjects)
+
+	versions := []registry.SubjectVersion{}
+	for _, subject := range subjects {
+		for _, v := range s.liveVersions(subject, false) {
+			if v.id == n {
+				versions = append(versions, registry.SubjectVersion{Subject: subject, Version: v.version})
+			}
+		}
+	}
+	return versions, nil
+}
+
+func (s *Server) getReferencedBy(subject, ver string) (any, *apiError) {
+	v, err := s.findVersion(subject, ver, true)
+	if err != nil {
+		return nil, err
+	}
+	return s.referencedBy(subject, v.version), nil
+}
+
+func (s *Server) registerSchema(r *http.Request, subject string) (any, *apiError) {
+	req, entry, err := s.readSchema(r)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.checkWritable(subject); err != nil {
+		return nil, err
+	}
+
+	versions := s.liveVersions(subject, false)
+	for _, v := range versions {
+		if s.sameSchema(s.schemaByID(v.id), entry) {
+			return idPayload{ID: v.id}, nil
+		}
+	}
+
+	mode := s.effectiveMode(subject)
+	if (req.ID > 0 || req.Version > 0) && mode != registry.ImportMode {
+		return nil, errorf(registry.CodeOperationNotPermitted, "Subject %s is not in import mode", subject)
+	}
+	if mode != registry.ImportMode {
+		if err = s.checkCompatibility(subject, versions, entry.parsed); err != nil {
+			return nil, err
+		}
+	}
+
+	id, err := s.schemaID(entry, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	next := req.Version
+	if next <= 0 {
+		next = 1
+		if all := s.subjects[subject]; len(all) > 0 {
+			next = all[len(all)-1].version + 1
+		}
+	}
+	for _, v := range s.subjects[subject] {
+		if v.version == next {
+			return nil, errorf(registry.CodeOperationNotPermitted,
+				"Overwrite new schema with version %d of subject %s is not permitted", next, subject)
+		}
+	}
+
+	s.subjects[subject] = append(s.subjects[subject], &version{version: next, id: id})
+	sort.Slice(s.subjects[subject], func(i, j int) bool {
+		return s.subjects[subject][i].version < s.subjects[subject][j].version
+	})
+	return idPayload{ID: id}, nil
+}
+
+func (s *Server) lookupSchema(r *http.Request, subject string) (any, *apiError) {
+	_, entry, err := s.readSchema(r)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := s.liveVersions(subject, false)
+	if len(versions) == 0 {
+		return nil, errorf(registry.CodeSubjectNotFound, "Subject '%s' not found.", subject)
+	}
+	for _, v := range versions {
+		if s.sameSchema(s.schemaByID(v.id), entry) {
+			return s.versionPayload(subject, v), nil
+		}
+	}
+	return nil, errorf(registry.CodeSchemaNotFound, "Schema not found")
+}
+
+func (s *Server) testCompatibility(r *http.Request, subject, ver string) (any, *apiError) {
+	_, entry, err := s.readSchema(r)
+	if err != nil {
+		return nil, err
+	}
+	v, err := s.findVersion(subject, ver, false)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := registry.CompatibilityResult{IsCompatible: true, Messages: []string{}}
+	if cerr := s.compatible(s.compatibilityLevel(subject), entry.parsed, s.schemaByID(v.id).parsed); cerr != nil {
+		resp.IsCompatible = false
+		resp.Messages = append(resp.Messages, cerr.Error())
+	}
+	return resp, nil
+}
+
+func (s *Server) deleteSubject(subject string, permanent bool) (any, *apiError) {
+	if err := s.checkWritable(subject); err != nil {
+		return nil, err
+	}
+
+	all := s.subjects[subject]
+	if len(all) == 0 {
+		return nil, errorf(registry.CodeSubjectNotFound, "Subject '%s' not found.", subject)
+	}
+	live := s.liveVersions(subject, false)
+	switch {
+	case !permanent && len(live) == 0:
+		return nil, errorf(registry.CodeSubjectSoftDeleted, "Subject '%s' was soft deleted.", subject)
+	case permanent && len(live) > 0:
+		return nil, errorf(registry.CodeSubjectNotSoftDeleted, "Subject '%s' was not deleted first before being permanently deleted", subject)
+	}
+	for _, v := range all {
+		if ids := s.referencedBy(subject, v.version); len(ids) > 0 {
+			return nil, errorf(registry.CodeReferenceExists, "One or more references exist to the schema {subject=%s,version=%d}", subject, v.version)
+		}
+	}
+
+	nums := []int{}
+	for _, v := range all {
+		if permanent || !v.deleted {
+			nums = append(nums, v.version)
+		}
+		v.deleted = true
+	}
+	if permanent {
+		delete(s.subjects, subject)
+		delete(s.subjectCompat, subject)
+		delete(s.subjectMode, subject)
+	}
+	return nums, nil
+}
+
+func (s *Server) deleteVersion(subject, ver string, permanent bool) (any, *apiError) {
+	if err := s.checkWritable(subject); err != nil {
+		return nil, err
+	}
+
+	v, err := s.findVersion(subject, ver, permanent)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case !permanent && v.deleted:
+		return nil, errorf(registry.CodeVersionSoftDeleted, "Subject '%s' Version %d was soft deleted.", subject, v.version)
+	case permanent && !v.deleted:
+		return nil, errorf(registry.CodeVersionNotSoftDeleted, "Subject '%s' Version %d was not deleted first before being permanently deleted", subject, v.version)
+	}
+	if ids := s.referencedBy(subject, v.version); len(ids) > 0 {
+		return nil, errorf(registry.CodeReferenceExists, "One or more references exist to the schema {subject=%s,version=%d}", subject, v.version)
+	}
+
+	v.deleted = true
+	if permanent {
+		all := s.subjects[subject]
+		for i := range all {
+			if all[i] == v {
+				s.subjects[subject] = append(all[:i:i], all[i+1:]...)
+				break
+			}
+		}
+		if len(s.subjects[subject]) == 0 {
+			delete(s.subjects, subject)
+		}
+	}
+	return v.version, nil
+}
+
+func (s *Server) getCompatibilityLevel(subject string, defaultToGlobal bool) (any, *apiError) {
+	lvl, ok := s.subjectCompat[subject]
+	if !ok {
+		if !defaultToGlobal {
+			return nil, errorf(registry.CodeSubjectCompatNotConfigured,
+				"Subject '%s' does not have subject-level compatibility configured", subject)
+		}
+		lvl = s.compatLevel
+	}
+	return compatLevelPayload{CompatibilityLevel: lvl}, nil
+}
+
+func (s *Server) setCompatibilityLevel(r *http.Request, subject string) (any, *apiError) {
+	var req compatPayload
+	if err := jsoniter.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errorf(registry.CodeInvalidCompatibilityLevel, "Invalid compatibility level")
+	}
+	switch req.Compatibility {
+	case registry.BackwardCL, registry.BackwardTransitiveCL, registry.ForwardCL, registry.ForwardTransitiveCL,
+		registry.FullCL, registry.FullTransitiveCL, registry.NoneCL:
+	default:
+		return nil, errorf(registry.CodeInvalidCompatibilityLevel, "Invalid compatibility level. Valid values are "+
+			"none, backward, forward, full, backward_transitive, forward_transitive, and full_transitive")
+	}
+
+	if subject == "" {
+		s.compatLevel = req.Compatibility
+	} else {
+		s.subjectCompat[subject] = req.Compatibility
+	}
+	return req, nil
+}
+
+func (s *Server) deleteCompatibilityLevel(subject string) (any, *apiError) {
+	lvl, ok := s.subjectCompat[subject]
+	if !ok {
+		return nil, errorf(registry.CodeSubjectNotFound, "Subject '%s' not found.", subject)
+	}
+	delete(s.subjectCompat, subject)
+	return compatLevelPayload{CompatibilityLevel: lvl}, nil
+}
+
+func (s *Server) getMode(subject string, defaultToGlobal bool) (any, *apiError) {
+	mode, ok := s.subjectMode[subject]
+	if !ok {
+		if !defaultToGlobal {
+			return nil, errorf(registry.CodeSubjectModeNotConfigured,
+				"Subject '%s' does not have subject-level mode configured", subject)
+		}
+		mode = s.mode
+	}
+	return modePayload{Mode: mode}, nil
+}
+
+func (s *Server) setMode(r *http.Request, subject string) (any, *apiError) {
+	var req modePayload
+	if err := jsoniter.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errorf(registry.CodeInvalidMode, "Invalid mode")
+	}
+	switch req.Mode {
+	case registry.ReadWriteMode, registry.ReadOnlyMode, registry.ImportMode:
+	default:
+		return nil, errorf(registry.CodeInvalidMode, "Invalid mode. Valid values are READWRITE, READONLY and IMPORT")
+	}
+
+	if req.Mode == registry.ImportMode && r.URL.Query().Get("force") != "true" {
+		empty := len(s.subjects) == 0
+		if subject != "" {
+			empty = len(s.subjects[subject]) == 0
+		}
+		if !empty {
+			return nil, errorf(registry.CodeOperationNotPermitted,
+				"Cannot import since found existing subjects")
+		}
+	}
+
+	if subject == "" {
+		s.mode = req.Mode
+	} else {
+		s.subjectMode[subject] = req.Mode
+	}
+	return req, nil
+}
+
+func (s *Server) deleteMode(subject string) (any, *apiError) {
+	mode, ok := s.subjectMode[subject]
+	if !ok {
+		return nil, errorf(registry.CodeSubjectNotFound, "Subject '%s' not found.", subject)
+	}
+	delete(s.subjectMode, subject)
+	return modePayload{Mode: mode}, nil
+}
+
+func (s *Server) effectiveMode(subject string) string {
+	if mode, ok := s.subjectMode[subject]; ok {
+		return mode
+	}
+	return s.mode
+}
+
+// checkWritable checks that the subject is not in read-only mode.
+func (s *Server) checkWritable(subject string) *apiError {
+	if s.effectiveMode(subject) == registry.ReadOnlyMode {
+		return errorf(registry.CodeOperationNotPermitted, "Subject %s is in read-only mode", subject)
+	}
+	return nil
+}
+
+// readSchema reads and parses the schema in the request body, resolving its references.
+func (s *Server) readSchema(r *http.Request) (schemaPayload, *schemaEntry, *apiError) {
+	var req schemaPayload
+	if err := jsoniter.NewDecoder(r.Body).Decode(&req); err != nil {
+		return req, nil, errorf(registry.CodeInvalidSchema, "Invalid schema: %v", err)
+	}
+	if req.SchemaType != "" && req.SchemaType != "AVRO" {
+		return req, nil, errorf(registry.CodeInvalidSchema, "Invalid schema type %s", req.SchemaType)
+	}
+
+	parsed, err := s.parse(req.Schema, req.References)
+	if err != nil {
+		return req, nil, errorf(registry.CodeInvalidSchema, "Invalid schema: %v", err)
+	}
+	return req, &schemaEntry{schema: req.Schema, refs: req.References, parsed: parsed}, nil
+}
+
+// parse parses a schema into a fresh cache, after parsing the schemas it references.
+func (s *Server) parse(schema string, refs []registry.SchemaReference) (avro.Schema, error) {
+	cache := &avro.SchemaCache{}
+	if err := s.parseReferences(cache, refs, map[int]bool{}); err != nil {
+		return nil, err
+	}
+	return avro.ParseWithCache(schema, "", cache)
+}
+
+func (s *Server) parseReferences(cache *avro.SchemaCache, refs []registry.SchemaReference, seen map[int]bool) error {
+	for _, ref := range refs {
+		ver := "latest"
+		if ref.Version > 0 {
+			ver = strconv.Itoa(ref.Version)
+		}
+		v, apiErr := s.findVersion(ref.Subject, ver, false)
+		if apiErr != nil {
+			return fmt.Errorf("reference %s: %s", ref.Name, apiErr.Message)
+		}
+		if seen[v.id] {
+			continue
+		}
+		seen[v.id] = true
+
+		entry := s.schemaByID(v.id)
+		if err := s.parseReferences(cache, entry.refs, seen); err != nil {
+			return err
+		}
+		if _, err := avro.ParseWithCache(entry.schema, "", cache); err != nil {
+			return fmt.Errorf("reference %s: %w", ref.Name, err)
+		}
+	}
+	return nil
+}
+
+// checkCompatibility checks a schema against the subject versions required by its compatibility level.
+func (s *Server) checkCompatibility(subject string, versions []*version, schema avro.Schema) *apiError {
+	lvl := s.compatibilityLevel(subject)
+	if len(versions) == 0 {
+		return nil
+	}
+	if !strings.HasSuffix(lvl, "_TRANSITIVE") {
+		versions = versions[len(versions)-1:]
+	}
+
+	for _, v := range versions {
+		if err := s.compatible(lvl, schema, s.schemaByID(v.id).parsed); err != nil {
+			return errorf(registry.CodeIncompatibleSchema,
+				"Schema being registered is incompatible with an earlier schema for subject \"%s\": version %d: %v",
+				subject, v.version, err)
+		}
+	}
+	return nil
+}
+
+func (s *Server) compatibilityLevel(subject string) string {
+	if lvl, ok := s.subjectCompat[subject]; ok {
+		return lvl
+	}
+	return s.compatLevel
+}
+
+// compatible checks a schema against a previous schema in the direction of the compatibility level.
+func (s *Server) compatible(lvl string, schema, prev avro.Schema) error {
+	switch strings.TrimSuffix(lvl, "_TRANSITIVE") {
+	case registry.BackwardCL:
+		return s.compat.Compatible(schema, prev)
+	case registry.ForwardCL:
+		return s.compat.Compatible(prev, schema)
+	case registry.FullCL:
+		if err := s.compat.Compatible(schema, prev); err != nil {
+			return err
+		}
+		return s.compat.Compatible(prev, schema)
+	default:
+		return nil
+	}
+}
+
+// schemaID returns the id of the schema, registering it if it is new.
+// An imported schema is registered with the requested id.
+func (s *Server) schemaID(entry *schemaEntry, id int) (int, *apiError) {
+	if id > 0 {
+		if e, ok := s.schemas[id]; ok && !s.sameSchema(e, entry) {
+			return 0, errorf(registry.CodeOperationNotPermitted, "Overwrite new schema with id %d is not permitted", id)
+		}
+		entry.id = id
+		s.schemas[id] = entry
+		return id, nil
+	}
+
+	for _, e := range s.schemas {
+		if s.sameSchema(e, entry) && (id == 0 || e.id < id) {
+			id = e.id
+		}
+	}
+	if id > 0 {
+		return id, nil
+	}
+
+	for id = range s.schemas {
+		if id > entry.id {
+			entry.id = id
+		}
+	}
+	entry.id++
+	s.schemas[entry.id] = entry
+	return entry.id, nil
+}
+
+func (s *Server) sameSchema(a, b *schemaEntry) bool {
+	if a.parsed.Fingerprint() != b.parsed.Fingerprint() || len(a.refs) != len(b.refs) {
+		return false
+	}
+	for i := range a.refs {
+		if a.refs[i] != b.refs[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// schemaByID returns the schema with the given id, if it is still used by a subject version.
+func (s *Server) schemaByID(id int) *schemaEntry {
+	if !s.inUse(id, true) {
+		return nil
+	}
+	return s.schemas[id]
+}
+
+// inUse determines if the schema id is used by a subject version, optionally including soft deleted versions.
+func (s *Server) inUse(id int, deleted bool) bool {
+	for _, versions := range s.subjects {
+		for _, v := range versions {
+			if v.id == id && (deleted || !v.deleted) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// liveVersions returns the versions of a subject, optionally including soft deleted versions.
+func (s *Server) liveVersions(subject string, deleted bool) []*version {
+	var versions []*version
+	for _, v := range s.subjects[subject] {
+		if deleted || !v.deleted {
+			versions = append(versions, v)
+		}
+	}
+	return versions
+}
+
+func (s *Server) findVersion(subject, ver string, deleted bool) (*version, *apiError) {
+	versions := s.liveVersions(subject, deleted)
+	if len(versions) == 0 {
+		return nil, errorf(registry.CodeSubjectNotFound, "Subject '%s' not found.", subject)
+	}
+
+	if ver == "latest" || ver == "-1" {
+		return versions[len(versions)-1], nil
+	}
+
+	n, err := strconv.Atoi(ver)
+	if err != nil || n < 1 {
+		return nil, errorf(registry.CodeInvalidVersion,
+			"The specified version '%s' is not a valid version id. "+
+				"Allowed values are between [1, 2^31-1] and the string \"latest\"", ver)
+	}
+	for _, v := range versions {
+		if v.version == n {
+			return v, nil
+		}
+	}
+	return nil, errorf(registry.CodeVersionNotFound, "Version %d not found.", n)
+}
+
+// referencedBy returns the ids of the schemas that reference the subject version.
+func (s *Server) referencedBy(subject string, ver int) []int {
+	ids := []int{}
+	for _, entry := range s.schemas {
+		if !s.inUse(entry.id, false) {
+			continue
+		}
+		for _, ref := range entry.refs {
+			if ref.Subject == subject && ref.Version == ver {
+				ids = append(ids, entry.id)
+				break
+			}
+		}
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+func (s *Server) versionPayload(subject string, v *version) versionPayload {
+	entry := s.schemaByID(v.id)
+	return versionPayload{
+		Subject:    subject,
+		ID:         v.id,
+		Version:    v.version,
+		Schema:     entry.schema,
+		References: entry.refs,
+	}
+}
@@ -0,0 +1,472 @@
+package registrytest_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kjuulh/avro/v2"
+	"github.com/kjuulh/avro/v2/registry"
+	"github.com/kjuulh/avro/v2/registry/registrytest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	userV1 = `{"type":"record","name":"User","namespace":"org.hamba.avro","fields":[{"name":"name","type":"string"}]}`
+	userV2 = `{"type":"record","name":"User","namespace":"org.hamba.avro","fields":[{"name":"name","type":"string"},{"name":"age","type":"int","default":0}]}`
+	userV3 = `{"type":"record","name":"User","namespace":"org.hamba.avro","fields":[{"name":"name","type":"string"},{"name":"email","type":"string"}]}`
+)
+
+func newClient(t *testing.T) (*registry.Client, string) {
+	t.Helper()
+
+	s := httptest.NewServer(registrytest.NewServer())
+	t.Cleanup(s.Close)
+
+	client, err := registry.NewClient(s.URL)
+	require.NoError(t, err)
+	return client, s.URL
+}
+
+func do(t *testing.T, method, url, body string) (int, string) {
+	t.Helper()
+
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	b, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, strings.TrimSpace(string(b))
+}
+
+func requireErrorCode(t *testing.T, err error, code int) {
+	t.Helper()
+
+	require.Error(t, err)
+	assert.True(t, registry.HasCode(err, code), "expected error code %d, got: %v", code, err)
+}
+
+func TestServer_CreateSchema(t *testing.T) {
+	client, _ := newClient(t)
+	ctx := context.Background()
+
+	id, schema, err := client.CreateSchema(ctx, "users-value", userV1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, id)
+	assert.Equal(t, avro.Record, schema.Type())
+
+	id, _, err = client.CreateSchema(ctx, "users-value", userV1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, id)
+
+	id, _, err = client.CreateSchema(ctx, "users-value", userV2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, id)
+
+	id, _, err = client.CreateSchema(ctx, "other-value", userV1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, id)
+
+	subjects, err := client.GetSubjects(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"other-value", "users-value"}, subjects)
+
+	versions, err := client.GetVersions(ctx, "users-value")
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, versions)
+
+	info, err := client.GetLatestSchemaInfo(ctx, "users-value")
+	require.NoError(t, err)
+	assert.Equal(t, 2, info.ID)
+	assert.Equal(t, 2, info.Version)
+
+	schema, err = client.GetSchema(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, info.Schema.Fingerprint(), schema.Fingerprint())
+}
+
+func TestServer_CreateSchemaInvalid(t *testing.T) {
+	client, _ := newClient(t)
+
+	_, _, err := client.CreateSchema(context.Background(), "users-value", `{"type":"record"}`)
+
+	requireErrorCode(t, err, registry.CodeInvalidSchema)
+}
+
+func TestServer_CreateSchemaIncompatible(t *testing.T) {
+	tests := []struct {
+		name    string
+		lvl     string
+		schemas []string
+		wantErr bool
+	}{
+		{
+			name:    "backward compatible",
+			lvl:     registry.BackwardCL,
+			schemas: []string{userV1, userV2},
+		},
+		{
+			name:    "backward incompatible",
+			lvl:     registry.BackwardCL,
+			schemas: []string{userV1, userV3},
+			wantErr: true,
+		},
+		{
+			name:    "forward compatible",
+			lvl:     registry.ForwardCL,
+			schemas: []string{userV1, userV3},
+		},
+		{
+			name:    "full incompatible",
+			lvl:     registry.FullCL,
+			schemas: []string{userV1, userV3},
+			wantErr: true,
+		},
+		{
+			name:    "backward only checks latest",
+			lvl:     registry.BackwardCL,
+			schemas: []string{userV3, userV1, userV2, userV3},
+		},
+		{
+			name:    "backward transitive checks all",
+			lvl:     registry.BackwardTransitiveCL,
+			schemas: []string{userV3, userV1, userV2, `"string"`},
+			wantErr: true,
+		},
+		{
+			name:    "none",
+			lvl:     registry.NoneCL,
+			schemas: []string{userV1, `"string"`},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			client, _ := newClient(t)
+			ctx := context.Background()
+
+			// Register the first schemas without checks, then check the last.
+			require.NoError(t, client.SetCompatibilityLevel(ctx, "users-value", registry.NoneCL))
+			for _, schema := range test.schemas[:len(test.schemas)-1] {
+				_, _, err := client.CreateSchema(ctx, "users-value", schema)
+				require.NoError(t, err)
+			}
+			require.NoError(t, client.SetCompatibilityLevel(ctx, "users-value", test.lvl))
+
+			_, _, err := client.CreateSchema(ctx, "users-value", test.schemas[len(test.schemas)-1])
+
+			if test.wantErr {
+				requireErrorCode(t, err, registry.CodeIncompatibleSchema)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestServer_IsRegistered(t *testing.T) {
+	client, _ := newClient(t)
+	ctx := context.Background()
+
+	_, _, err := client.IsRegistered(ctx, "users-value", userV1)
+	requireErrorCode(t, err, registry.CodeSubjectNotFound)
+
+	_, _, err = client.CreateSchema(ctx, "users-value", userV1)
+	require.NoError(t, err)
+
+	id, _, err := client.IsRegistered(ctx, "users-value", userV1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, id)
+
+	_, _, err = client.IsRegistered(ctx, "users-value", userV2)
+	requireErrorCode(t, err, registry.CodeSchemaNotFound)
+}
+
+func TestServer_GetSchemaNotFound(t *testing.T) {
+	client, _ := newClient(t)
+	ctx := context.Background()
+
+	_, err := client.GetSchema(ctx, 1)
+	requireErrorCode(t, err, registry.CodeSchemaNotFound)
+
+	_, err = client.GetLatestSchema(ctx, "users-value")
+	requireErrorCode(t, err, registry.CodeSubjectNotFound)
+
+	_, _, err = client.CreateSchema(ctx, "users-value", userV1)
+	require.NoError(t, err)
+
+	_, err = client.GetSchemaByVersion(ctx, "users-value", 2)
+	requireErrorCode(t, err, registry.CodeVersionNotFound)
+
+	_, err = client.GetSchemaByVersion(ctx, "users-value", 0)
+	requireErrorCode(t, err, registry.CodeInvalidVersion)
+}
+
+func TestServer_References(t *testing.T) {
+	client, _ := newClient(t)
+	ctx := context.Background()
+
+	_, _, err := client.CreateSchema(ctx, "kind", `{"type":"enum","name":"Kind","namespace":"org.hamba.avro","symbols":["A","B"]}`)
+	require.NoError(t, err)
+
+	refs := []registry.SchemaReference{{Name: "org.hamba.avro.Kind", Subject: "kind", Version: 1}}
+	schema := `{"type":"record","name":"Thing","namespace":"org.hamba.avro","fields":[{"name":"kind","type":"Kind"}]}`
+
+	_, _, err = client.CreateSchema(ctx, "things-value", schema)
+	requireErrorCode(t, err, registry.CodeInvalidSchema)
+
+	id, _, err := client.CreateSchema(ctx, "things-value", schema, refs...)
+	require.NoError(t, err)
+	assert.Equal(t, 2, id)
+
+	got, err := client.GetSchema(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, "org.hamba.avro.Thing", got.(avro.NamedSchema).FullName())
+
+	ids, err := client.GetReferencedBy(ctx, "kind", 1)
+	require.NoError(t, err)
+	assert.Equal(t, []int{2}, ids)
+
+	_, err = client.DeleteSubject(ctx, "kind")
+	requireErrorCode(t, err, registry.CodeReferenceExists)
+}
+
+func TestServer_DeleteSubject(t *testing.T) {
+	client, url := newClient(t)
+	ctx := context.Background()
+
+	_, _, err := client.CreateSchema(ctx, "users-value", userV1)
+	require.NoError(t, err)
+	_, _, err = client.CreateSchema(ctx, "users-value", userV2)
+	require.NoError(t, err)
+
+	_, err = client.DeleteSubjectPermanent(ctx, "users-value")
+	requireErrorCode(t, err, registry.CodeSubjectNotSoftDeleted)
+
+	versions, err := client.DeleteSubject(ctx, "users-value")
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, versions)
+
+	_, err = client.DeleteSubject(ctx, "users-value")
+	requireErrorCode(t, err, registry.CodeSubjectSoftDeleted)
+
+	subjects, err := client.GetSubjects(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, subjects)
+
+	_, body := do(t, http.MethodGet, url+"/subjects?deleted=true", "")
+	assert.Equal(t, `["users-value"]`, body)
+
+	_, err = client.GetSchema(ctx, 1)
+	require.NoError(t, err, "soft deleted schemas are still available by id")
+
+	versions, err = client.DeleteSubjectPermanent(ctx, "users-value")
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, versions)
+
+	_, err = client.DeleteSubject(ctx, "users-value")
+	requireErrorCode(t, err, registry.CodeSubjectNotFound)
+
+	status, body := do(t, http.MethodGet, url+"/schemas/ids/1", "")
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.Contains(t, body, "40403")
+}
+
+func TestServer_DeleteVersion(t *testing.T) {
+	client, url := newClient(t)
+	ctx := context.Background()
+
+	_, _, err := client.CreateSchema(ctx, "users-value", userV1)
+	require.NoError(t, err)
+	_, _, err = client.CreateSchema(ctx, "users-value", userV2)
+	require.NoError(t, err)
+
+	status, body := do(t, http.MethodDelete, url+"/subjects/users-value/versions/latest", "")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "2", body)
+
+	_, err = client.DeleteVersion(ctx, "users-value", 2)
+	requireErrorCode(t, err, registry.CodeVersionNotFound)
+
+	info, err := client.GetLatestSchemaInfo(ctx, "users-value")
+	require.NoError(t, err)
+	assert.Equal(t, 1, info.Version)
+
+	_, err = client.DeleteVersionPermanent(ctx, "users-value", 1)
+	requireErrorCode(t, err, registry.CodeVersionNotSoftDeleted)
+
+	version, err := client.DeleteVersionPermanent(ctx, "users-value", 2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, version)
+
+	_, body = do(t, http.MethodGet, url+"/subjects/users-value/versions?deleted=true", "")
+	assert.Equal(t, "[1]", body)
+
+	id, _, err := client.CreateSchema(ctx, "users-value", userV2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, id)
+	versions, err := client.GetVersions(ctx, "users-value")
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, versions)
+}
+
+func TestServer_CompatibilityLevel(t *testing.T) {
+	client, url := newClient(t)
+	ctx := context.Background()
+
+	_, body := do(t, http.MethodGet, url+"/config", "")
+	assert.Equal(t, `{"compatibilityLevel":"BACKWARD"}`, body)
+
+	_, err := client.GetCompatibilityLevel(ctx, "users-value")
+	requireErrorCode(t, err, registry.CodeSubjectCompatNotConfigured)
+
+	_, body = do(t, http.MethodGet, url+"/config/users-value?defaultToGlobal=true", "")
+	assert.Equal(t, `{"compatibilityLevel":"BACKWARD"}`, body)
+
+	require.NoError(t, client.SetGlobalCompatibilityLevel(ctx, registry.FullCL))
+	require.NoError(t, client.SetCompatibilityLevel(ctx, "users-value", registry.NoneCL))
+
+	_, body = do(t, http.MethodGet, url+"/config", "")
+	assert.Equal(t, `{"compatibilityLevel":"FULL"}`, body)
+	_, body = do(t, http.MethodGet, url+"/config/users-value", "")
+	assert.Equal(t, `{"compatibilityLevel":"NONE"}`, body)
+
+	status, body := do(t, http.MethodPut, url+"/config", `{"compatibility":"SOMETIMES"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, status)
+	assert.Contains(t, body, "42203")
+}
+
+func TestServer_GetSubjectVersionsByID(t *testing.T) {
+	client, _ := newClient(t)
+	ctx := context.Background()
+
+	_, err := client.GetSubjectVersionsByID(ctx, 1)
+	requireErrorCode(t, err, registry.CodeSchemaNotFound)
+
+	_, _, err = client.CreateSchema(ctx, "users-value", userV1)
+	require.NoError(t, err)
+	_, _, err = client.CreateSchema(ctx, "others-value", userV1)
+	require.NoError(t, err)
+
+	versions, err := client.GetSubjectVersionsByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []registry.SubjectVersion{{Subject: "others-value", Version: 1}, {Subject: "users-value", Version: 1}}, versions)
+}
+
+func TestServer_CheckCompatibility(t *testing.T) {
+	client, _ := newClient(t)
+	ctx := context.Background()
+
+	_, err := client.CheckLatestCompatibility(ctx, "users-value", userV2)
+	requireErrorCode(t, err, registry.CodeSubjectNotFound)
+
+	_, _, err = client.CreateSchema(ctx, "users-value", userV1)
+	require.NoError(t, err)
+
+	res, err := client.CheckLatestCompatibility(ctx, "users-value", userV2)
+	require.NoError(t, err)
+	assert.True(t, res.IsCompatible)
+	assert.Empty(t, res.Messages)
+
+	res, err = client.CheckCompatibility(ctx, "users-value", 1, userV3)
+	require.NoError(t, err)
+	assert.False(t, res.IsCompatible)
+	assert.Len(t, res.Messages, 1)
+
+	_, err = client.CheckCompatibility(ctx, "users-value", 2, userV2)
+	requireErrorCode(t, err, registry.CodeVersionNotFound)
+
+	_, err = client.CheckCompatibility(ctx, "users-value", 1, `{"type":"record"}`)
+	requireErrorCode(t, err, registry.CodeInvalidSchema)
+
+	require.NoError(t, client.SetCompatibilityLevel(ctx, "users-value", registry.ForwardCL))
+	res, err = client.CheckCompatibility(ctx, "users-value", 1, userV3)
+	require.NoError(t, err)
+	assert.True(t, res.IsCompatible)
+}
+
+func TestServer_ReadOnlyMode(t *testing.T) {
+	client, _ := newClient(t)
+	ctx := context.Background()
+
+	mode, err := client.GetGlobalMode(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, registry.ReadWriteMode, mode)
+
+	_, err = client.GetMode(ctx, "users-value")
+	requireErrorCode(t, err, registry.CodeSubjectModeNotConfigured)
+
+	_, _, err = client.CreateSchema(ctx, "users-value", userV1)
+	require.NoError(t, err)
+	require.NoError(t, client.SetMode(ctx, "users-value", registry.ReadOnlyMode))
+
+	mode, err = client.GetMode(ctx, "users-value")
+	require.NoError(t, err)
+	assert.Equal(t, registry.ReadOnlyMode, mode)
+
+	_, _, err = client.CreateSchema(ctx, "users-value", userV2)
+	requireErrorCode(t, err, registry.CodeOperationNotPermitted)
+	_, err = client.DeleteSubject(ctx, "users-value")
+	requireErrorCode(t, err, registry.CodeOperationNotPermitted)
+
+	_, _, err = client.CreateSchema(ctx, "others-value", userV2)
+	require.NoError(t, err)
+
+	require.NoError(t, client.SetGlobalMode(ctx, registry.ReadOnlyMode))
+	_, _, err = client.CreateSchema(ctx, "others-value", userV3)
+	requireErrorCode(t, err, registry.CodeOperationNotPermitted)
+}
+
+func TestServer_ImportMode(t *testing.T) {
+	client, url := newClient(t)
+	ctx := context.Background()
+
+	_, _, err := client.CreateSchema(ctx, "users-value", userV1)
+	require.NoError(t, err)
+
+	err = client.SetGlobalMode(ctx, registry.ImportMode)
+	requireErrorCode(t, err, registry.CodeOperationNotPermitted)
+
+	status, body := do(t, http.MethodPost, url+"/subjects/imported-value/versions", `{"schema":"\"string\"","id":10,"version":3}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, status)
+	assert.Contains(t, body, "42205")
+
+	require.NoError(t, client.SetMode(ctx, "imported-value", registry.ImportMode))
+
+	status, body = do(t, http.MethodPost, url+"/subjects/imported-value/versions", `{"schema":"\"string\"","id":10,"version":3}`)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, `{"id":10}`, body)
+
+	status, body = do(t, http.MethodPost, url+"/subjects/imported-value/versions", `{"schema":"\"int\"","id":10,"version":4}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, status)
+	assert.Contains(t, body, "42205")
+
+	info, err := client.GetLatestSchemaInfo(ctx, "imported-value")
+	require.NoError(t, err)
+	assert.Equal(t, 10, info.ID)
+	assert.Equal(t, 3, info.Version)
+
+	require.NoError(t, client.SetMode(ctx, "imported-value", registry.ReadWriteMode))
+	id, _, err := client.CreateSchema(ctx, "imported-value", `"string"`)
+	require.NoError(t, err)
+	assert.Equal(t, 10, id)
+	id, _, err = client.CreateSchema(ctx, "other-value", `"long"`)
+	require.NoError(t, err)
+	assert.Equal(t, 11, id)
+}
+
+func TestServer_NotFound(t *testing.T) {
+	_, url := newClient(t)
+
+	status, _ := do(t, http.MethodGet, url+"/foo", "")
+
+	assert.Equal(t, http.StatusNotFound, status)
+}
@@ -323,6 +323,16 @@ func cacheFingerprintOf(schema Schema) [32]byte {
 	return schema.Fingerprint()
 }
 
+// fullFingerprintOf returns a fingerprint of the full schema, including the
+// defaults, aliases, logical types and field orders dropped by its canonical form.
+func fullFingerprintOf(schema Schema) ([32]byte, error) {
+	b, err := jsoniter.Marshal(schema)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return sha256.Sum256(b), nil
+}
+
 type cacheFingerprinter struct {
 	key atomic.Value // [32]byte
 }
@@ -662,6 +672,12 @@ func (s *RecordSchema) CacheFingerprint() [32]byte {
 		if field.action == FieldIgnore {
 			data = append(data, field.Name()+string(FieldIgnore))
 		}
+		if field.order != Asc {
+			data = append(data, field.Name(), field.order)
+		}
+		if fp := cacheFingerprintOf(field.Type()); fp != field.Type().Fingerprint() {
+			data = append(data, field.Name(), fp)
+		}
 	}
 	if len(data) == 0 {
 		return s.Fingerprint()
@@ -1024,6 +1040,7 @@ func (s *EnumSchema) CacheFingerprint() [32]byte {
 type ArraySchema struct {
 	properties
 	fingerprinter
+	cacheFingerprinter
 
 	items Schema
 }
@@ -1083,10 +1100,21 @@ func (s *ArraySchema) FingerprintUsing(typ FingerprintType) ([]byte, error) {
 	return s.fingerprinter.FingerprintUsing(typ, s)
 }
 
+// CacheFingerprint returns a special fingerprint of the schema for caching purposes.
+func (s *ArraySchema) CacheFingerprint() [32]byte {
+	items := cacheFingerprintOf(s.items)
+	if items == s.items.Fingerprint() {
+		return s.Fingerprint()
+	}
+
+	return s.cacheFingerprinter.fingerprint([]any{s.Fingerprint(), items})
+}
+
 // MapSchema is an Avro map type schema.
 type MapSchema struct {
 	properties
 	fingerprinter
+	cacheFingerprinter
 
 	values Schema
 }
@@ -1146,11 +1174,27 @@ func (s *MapSchema) FingerprintUsing(typ FingerprintType) ([]byte, error) {
 	return s.fingerprinter.FingerprintUsing(typ, s)
 }
 
+// CacheFingerprint returns a special fingerprint of the schema for caching purposes.
+func (s *MapSchema) CacheFingerprint() [32]byte {
+	values := cacheFingerprintOf(s.values)
+	if values == s.values.Fingerprint() {
+		return s.Fingerprint()
+	}
+
+	return s.cacheFingerprinter.fingerprint([]any{s.Fingerprint(), values})
+}
+
 // UnionSchema is an Avro union type schema.
 type UnionSchema struct {
 	fingerprinter
+	cacheFingerprinter
 
 	types Schemas
+
+	// resolved is set when a writer schema that is not a union was resolved
+	// against this union, in which case resolvedIdx is the matching type.
+	resolved    bool
+	resolvedIdx int
 }
 
 // NewUnionSchema creates a union schema instance.
@@ -1234,6 +1278,24 @@ func (s *UnionSchema) FingerprintUsing(typ FingerprintType) ([]byte, error) {
 	return s.fingerprinter.FingerprintUsing(typ, s)
 }
 
+// CacheFingerprint returns a special fingerprint of the schema for caching purposes.
+func (s *UnionSchema) CacheFingerprint() [32]byte {
+	data := make([]any, 0)
+	for i, typ := range s.types {
+		if fp := cacheFingerprintOf(typ); fp != typ.Fingerprint() {
+			data = append(data, i, fp)
+		}
+	}
+	if s.resolved {
+		data = append(data, "resolved", s.resolvedIdx)
+	}
+	if len(data) == 0 {
+		return s.Fingerprint()
+	}
+	data = append(data, s.Fingerprint())
+	return s.cacheFingerprinter.fingerprint(data)
+}
+
 // FixedSchema is an Avro fixed type schema.
 type FixedSchema struct {
 	name
@@ -290,12 +290,19 @@ func (c *SchemaCompatibility) resolve(reader, writer Schema) (Schema, error) {
 
 	if writer.Type() != reader.Type() {
 		if reader.Type() == Union {
-			for _, schema := range reader.(*UnionSchema).Types() {
+			union := reader.(*UnionSchema)
+			for i, schema := range union.Types() {
 				sch, err := c.Resolve(schema, writer)
 				if err != nil {
 					continue
 				}
-				return sch, nil
+
+				// The data has no union index, so the reader union is kept
+				// with the matching type marked as the one that was written.
+				types := make([]Schema, len(union.Types()))
+				copy(types, union.Types())
+				types[i] = sch
+				return &UnionSchema{types: types, resolved: true, resolvedIdx: i}, nil
 			}
 
 			return nil, fmt.Errorf("reader union lacking writer schema %s", writer.Type())
@@ -304,7 +311,7 @@ func (c *SchemaCompatibility) resolve(reader, writer Schema) (Schema, error) {
 		if writer.Type() == Union {
 			schemas := make([]Schema, 0)
 			for _, schema := range writer.(*UnionSchema).Types() {
-				sch, err := c.Resolve(reader, schema)
+				sch, err := c.resolveBranch(reader, schema)
 				if err != nil {
 					return nil, err
 				}
@@ -351,7 +358,7 @@ func (c *SchemaCompatibility) resolve(reader, writer Schema) (Schema, error) {
 	if writer.Type() == Union {
 		schemas := make([]Schema, 0)
 		for _, schema := range writer.(*UnionSchema).Types() {
-			sch, err := c.Resolve(reader, schema)
+			sch, err := c.resolveBranch(reader, schema)
 			if err != nil {
 				return nil, err
 			}
@@ -383,6 +390,20 @@ func (c *SchemaCompatibility) resolve(reader, writer Schema) (Schema, error) {
 	return nil, fmt.Errorf("failed to resolve composite schema for %s and %s", reader.Type(), writer.Type())
 }
 
+// resolveBranch resolves a type of a writer union. The union index is present
+// in the data, so a resolved reader union is reduced to its matching type.
+func (c *SchemaCompatibility) resolveBranch(reader, writer Schema) (Schema, error) {
+	sch, err := c.Resolve(reader, writer)
+	if err != nil {
+		return nil, err
+	}
+
+	if union, ok := sch.(*UnionSchema); ok && union.resolved {
+		return union.types[union.resolvedIdx], nil
+	}
+	return sch, nil
+}
+
 func (c *SchemaCompatibility) resolveRecord(reader, writer Schema) (Schema, error) {
 	w := writer.(*RecordSchema)
 	r := reader.(*RecordSchema)
@@ -0,0 +1,61 @@
+package soe
+
+import "github.com/kjuulh/avro/v2"
+
+// DecoderFunc is a function used to customize the Decoder.
+type DecoderFunc func(*Decoder)
+
+// WithAPI sets the avro configuration on the decoder.
+func WithAPI(api avro.API) DecoderFunc {
+	return func(d *Decoder) {
+		d.api = api
+	}
+}
+
+// WithReaderSchema sets the reader schema the data is resolved into.
+//
+// When set, each writer schema is resolved against the reader schema
+// following the Avro schema resolution rules.
+func WithReaderSchema(schema avro.Schema) DecoderFunc {
+	return func(d *Decoder) {
+		d.reader = schema
+	}
+}
+
+// Decoder decodes single object encoded payloads.
+type Decoder struct {
+	store  SchemaStore
+	api    avro.API
+	reader avro.Schema
+}
+
+// NewDecoder returns a decoder that will get writer schemas from store.
+func NewDecoder(store SchemaStore, opts ...DecoderFunc) *Decoder {
+	d := &Decoder{
+		store: store,
+		api:   avro.DefaultConfig,
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// Decode decodes data into v.
+// The data must be single object encoded, otherwise an error will be returned.
+func (d *Decoder) Decode(data []byte, v any) error {
+	fp, payload, err := ParseHeader(data)
+	if err != nil {
+		return err
+	}
+
+	schema, err := d.store.Schema(fp)
+	if err != nil {
+		return err
+	}
+
+	if d.reader != nil {
+		return d.api.UnmarshalWithWriterSchema(d.reader, schema, payload, v)
+	}
+	return d.api.Unmarshal(schema, payload, v)
+}
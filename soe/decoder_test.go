@@ -0,0 +1,142 @@
+package soe_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kjuulh/avro/v2"
+	"github.com/kjuulh/avro/v2/soe"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecoder_Decode(t *testing.T) {
+	schema := avro.MustParse(`"string"`)
+	store, err := soe.NewMemoryStore(schema)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		data    []byte
+		want    string
+		wantErr require.ErrorAssertionFunc
+	}{
+		{
+			name:    "decodes data",
+			data:    []byte{0xc3, 0x01, 0xc7, 0x03, 0x45, 0x63, 0x72, 0x48, 0x01, 0x8f, 0x06, 0x66, 0x6f, 0x6f},
+			want:    "foo",
+			wantErr: require.NoError,
+		},
+		{
+			name:    "handles short data",
+			data:    []byte{0xc3, 0x01, 0xc7, 0x03, 0x45},
+			wantErr: require.Error,
+		},
+		{
+			name:    "handles bad magic",
+			data:    []byte{0xc3, 0x02, 0xc7, 0x03, 0x45, 0x63, 0x72, 0x48, 0x01, 0x8f, 0x06, 0x66, 0x6f, 0x6f},
+			wantErr: require.Error,
+		},
+		{
+			name:    "handles unknown fingerprint",
+			data:    []byte{0xc3, 0x01, 0xc7, 0x03, 0x45, 0x63, 0x72, 0x48, 0x01, 0x8e, 0x06, 0x66, 0x6f, 0x6f},
+			wantErr: require.Error,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			dec := soe.NewDecoder(store)
+
+			var got string
+			err := dec.Decode(test.data, &got)
+
+			test.wantErr(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestDecoder_DecodeWithReaderSchema(t *testing.T) {
+	writer := avro.MustParse(`{
+	"type": "record",
+	"name": "test",
+	"fields" : [
+		{"name": "a", "type": "int"},
+		{"name": "b", "type": "string"}
+	]
+}`)
+	reader := avro.MustParse(`{
+	"type": "record",
+	"name": "test",
+	"fields" : [
+		{"name": "b", "type": "string"},
+		{"name": "a", "type": "long"},
+		{"name": "c", "type": "string", "default": "bar"}
+	]
+}`)
+	store, err := soe.NewMemoryStore(writer)
+	require.NoError(t, err)
+	enc, err := soe.NewEncoder(writer)
+	require.NoError(t, err)
+	data, err := enc.Encode(map[string]any{"a": 27, "b": "foo"})
+	require.NoError(t, err)
+
+	dec := soe.NewDecoder(store, soe.WithReaderSchema(reader), soe.WithAPI(avro.Config{}.Freeze()))
+
+	type record struct {
+		A int64  `avro:"a"`
+		B string `avro:"b"`
+		C string `avro:"c"`
+	}
+	for i := 0; i < 2; i++ {
+		var got record
+		err = dec.Decode(data, &got)
+
+		require.NoError(t, err)
+		assert.Equal(t, record{A: 27, B: "foo", C: "bar"}, got)
+	}
+}
+
+func TestDecoder_DecodeWithIncompatibleReaderSchema(t *testing.T) {
+	writer := avro.MustParse(`"string"`)
+	store, err := soe.NewMemoryStore(writer)
+	require.NoError(t, err)
+	enc, err := soe.NewEncoder(writer)
+	require.NoError(t, err)
+	data, err := enc.Encode("foo")
+	require.NoError(t, err)
+
+	dec := soe.NewDecoder(store, soe.WithReaderSchema(avro.MustParse(`"int"`)))
+
+	var got int
+	err = dec.Decode(data, &got)
+
+	assert.Error(t, err)
+}
+
+func TestMemoryStore_Schema(t *testing.T) {
+	schema := avro.MustParse(`"string"`)
+	store, err := soe.NewMemoryStore()
+	require.NoError(t, err)
+	require.NoError(t, store.Add(schema))
+
+	fp, err := soe.Fingerprint(schema)
+	require.NoError(t, err)
+
+	got, err := store.Schema(fp)
+	require.NoError(t, err)
+	assert.Equal(t, schema, got)
+
+	_, err = store.Schema(fp + 1)
+	assert.True(t, errors.Is(err, soe.ErrSchemaNotFound))
+}
+
+func TestParseHeader(t *testing.T) {
+	fp, payload, err := soe.ParseHeader([]byte{0xc3, 0x01, 0x8a, 0x8f, 0x25, 0xcc, 0xe7, 0x24, 0xdd, 0x63, 0x01})
+
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0x63dd24e7cc258f8a), fp)
+	assert.Equal(t, []byte{0x01}, payload)
+}
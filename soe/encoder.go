@@ -0,0 +1,60 @@
+package soe
+
+import (
+	"encoding/binary"
+
+	"github.com/kjuulh/avro/v2"
+)
+
+// EncoderFunc is a function used to customize the Encoder.
+type EncoderFunc func(*Encoder)
+
+// WithEncoderAPI sets the avro configuration on the encoder.
+func WithEncoderAPI(api avro.API) EncoderFunc {
+	return func(e *Encoder) {
+		e.api = api
+	}
+}
+
+// Encoder encodes values in the single object encoding.
+type Encoder struct {
+	api    avro.API
+	schema avro.Schema
+	header [HeaderSize]byte
+}
+
+// NewEncoder returns an encoder that writes values using schema.
+func NewEncoder(schema avro.Schema, opts ...EncoderFunc) (*Encoder, error) {
+	fp, err := Fingerprint(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &Encoder{
+		api:    avro.DefaultConfig,
+		schema: schema,
+	}
+	copy(e.header[:], magicBytes[:])
+	binary.LittleEndian.PutUint64(e.header[2:], fp)
+
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e, nil
+}
+
+// Encode returns the single object encoding of v.
+func (e *Encoder) Encode(v any) ([]byte, error) {
+	return e.AppendEncode(nil, v)
+}
+
+// AppendEncode appends the single object encoding of v to dst.
+func (e *Encoder) AppendEncode(dst []byte, v any) ([]byte, error) {
+	b, err := e.api.Marshal(e.schema, v)
+	if err != nil {
+		return dst, err
+	}
+
+	dst = append(dst, e.header[:]...)
+	return append(dst, b...), nil
+}
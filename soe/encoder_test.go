@@ -0,0 +1,44 @@
+package soe_test
+
+import (
+	"testing"
+
+	"github.com/kjuulh/avro/v2"
+	"github.com/kjuulh/avro/v2/soe"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncoder_Encode(t *testing.T) {
+	schema := avro.MustParse(`"string"`)
+	enc, err := soe.NewEncoder(schema)
+	require.NoError(t, err)
+
+	got, err := enc.Encode("foo")
+
+	require.NoError(t, err)
+	want := []byte{0xc3, 0x01, 0xc7, 0x03, 0x45, 0x63, 0x72, 0x48, 0x01, 0x8f, 0x06, 0x66, 0x6f, 0x6f}
+	assert.Equal(t, want, got)
+}
+
+func TestEncoder_AppendEncode(t *testing.T) {
+	schema := avro.MustParse(`"string"`)
+	enc, err := soe.NewEncoder(schema, soe.WithEncoderAPI(avro.Config{}.Freeze()))
+	require.NoError(t, err)
+
+	got, err := enc.AppendEncode([]byte{0xff}, "foo")
+
+	require.NoError(t, err)
+	want := []byte{0xff, 0xc3, 0x01, 0xc7, 0x03, 0x45, 0x63, 0x72, 0x48, 0x01, 0x8f, 0x06, 0x66, 0x6f, 0x6f}
+	assert.Equal(t, want, got)
+}
+
+func TestEncoder_EncodeError(t *testing.T) {
+	schema := avro.MustParse(`"string"`)
+	enc, err := soe.NewEncoder(schema)
+	require.NoError(t, err)
+
+	_, err = enc.Encode(1)
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,87 @@
+// Package soe implements the Avro single object encoding as defined by the Avro specification.
+//
+// A single object encoded payload is made of the two byte marker 0xC3 0x01,
+// the 8 byte little-endian CRC-64-AVRO fingerprint of the writer schema and
+// the Avro binary encoded value.
+//
+// See the Avro specification for an understanding of Avro: http://avro.apache.org/docs/current/
+package soe
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/kjuulh/avro/v2"
+)
+
+// HeaderSize is the size in bytes of the single object encoding header.
+const HeaderSize = 10
+
+var magicBytes = [2]byte{0xc3, 0x01}
+
+// ErrSchemaNotFound is returned when a fingerprint is not known by a SchemaStore.
+var ErrSchemaNotFound = errors.New("soe: schema not found")
+
+// Fingerprint returns the CRC-64-AVRO fingerprint of the schema, as used in the header.
+func Fingerprint(schema avro.Schema) (uint64, error) {
+	b, err := schema.FingerprintUsing(avro.CRC64Avro)
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(b), nil
+}
+
+// ParseHeader returns the writer schema fingerprint and the encoded value of
+// single object encoded data.
+func ParseHeader(data []byte) (uint64, []byte, error) {
+	if len(data) < HeaderSize {
+		return 0, nil, errors.New("soe: data too short")
+	}
+	if data[0] != magicBytes[0] || data[1] != magicBytes[1] {
+		return 0, nil, fmt.Errorf("soe: invalid magic bytes: %x", data[:2])
+	}
+	return binary.LittleEndian.Uint64(data[2:HeaderSize]), data[HeaderSize:], nil
+}
+
+// SchemaStore resolves writer schemas by their CRC-64-AVRO fingerprint.
+type SchemaStore interface {
+	// Schema returns the schema with the given fingerprint, or ErrSchemaNotFound.
+	Schema(fingerprint uint64) (avro.Schema, error)
+}
+
+// MemoryStore is an in-memory SchemaStore.
+type MemoryStore struct {
+	schemas sync.Map // map[uint64]avro.Schema
+}
+
+// NewMemoryStore returns a memory store containing the given schemas.
+func NewMemoryStore(schemas ...avro.Schema) (*MemoryStore, error) {
+	s := &MemoryStore{}
+	for _, schema := range schemas {
+		if err := s.Add(schema); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// Add adds a schema to the store.
+func (s *MemoryStore) Add(schema avro.Schema) error {
+	fp, err := Fingerprint(schema)
+	if err != nil {
+		return err
+	}
+	s.schemas.Store(fp, schema)
+	return nil
+}
+
+// Schema returns the schema with the given fingerprint, or ErrSchemaNotFound.
+func (s *MemoryStore) Schema(fingerprint uint64) (avro.Schema, error) {
+	schema, ok := s.schemas.Load(fingerprint)
+	if !ok {
+		return nil, fmt.Errorf("%w: fingerprint %x", ErrSchemaNotFound, fingerprint)
+	}
+	return schema.(avro.Schema), nil
+}
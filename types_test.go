@@ -22,6 +22,21 @@ type TestNestedRecord struct {
 	B TestRecord `avro:"b"`
 }
 
+type TestLinkedList struct {
+	Value int64           `avro:"value"`
+	Next  *TestLinkedList `avro:"next"`
+}
+
+type TestTree struct {
+	Value    int64      `avro:"value"`
+	Children []TestTree `avro:"children"`
+}
+
+type TestMapTree struct {
+	Value    int64                  `avro:"value"`
+	Children map[string]TestMapTree `avro:"children"`
+}
+
 type TestUnion struct {
 	A any `avro:"a"`
 }